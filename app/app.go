@@ -0,0 +1,94 @@
+package app
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/config"
+	"opendavinci/metrics"
+	"opendavinci/routes"
+	"opendavinci/tracing"
+)
+
+// Config struct to describe settings of the application.
+type Config struct {
+	ReadTimeout  time.Duration
+	BodyLimit    int  // in bytes, 0 keeps Fiber's 4 MB default
+	LegacyRoutes bool // register the pre-v1 /api/courses handlers
+	Metrics      bool // serve /metrics on the API port
+	GraphQL      bool // serve /graphql
+	GRPC         bool // serve the gRPC services to Connect and gRPC-Web clients
+	Events       bool // serve the change feed at /api/v1/events
+	WorkerOnly   bool // serve health and metrics only, the process runs background jobs
+
+	ValidateRequests  bool // reject requests which do not match the OpenAPI document
+	ValidateResponses bool // development: fail responses which do not match the document
+}
+
+// NewConfig func for getting application settings from loaded configuration.
+func NewConfig(cfg *config.Config) Config {
+	return Config{
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		BodyLimit:    cfg.Server.BodyLimitMB << 20,
+		LegacyRoutes: cfg.Server.LegacyRoutes, // off, unless old clients still need it
+		Metrics:      cfg.Metrics.Enabled && cfg.Metrics.Addr == "",
+		GraphQL:      cfg.GraphQL.Enabled,
+		GRPC:         cfg.GRPC.Enabled,
+		Events:       cfg.Events.Enabled,
+		WorkerOnly:   cfg.Jobs.Mode == "worker",
+
+		ValidateRequests:  cfg.Server.ValidateRequests,
+		ValidateResponses: cfg.Server.ValidateResponses,
+	}
+}
+
+// New func for create the Fiber app with middlewares and all routes.
+// The app does not listen, so tests can call app.Test on it.
+func New(cfg Config) *fiber.App {
+	a := fiber.New(fiber.Config{
+		ReadTimeout:  cfg.ReadTimeout,
+		BodyLimit:    cfg.BodyLimit,
+		ErrorHandler: routes.ErrorHandler,
+	})
+
+	// Keep-alive connections get "Connection: close" while shutting down.
+	a.Server().CloseOnShutdown = true
+
+	// Middlewares.
+	a.Use(countRequests)
+	routes.HealthRoutes(a) // before logger and metrics, probes are not logged
+	if cfg.Metrics {
+		routes.MetricsRoutes(a)
+	}
+	if cfg.WorkerOnly {
+		routes.NotFoundRoute(a)
+		return a
+	}
+	a.Use(metrics.Middleware)
+	a.Use(tracing.Middleware)
+	routes.FiberMiddleware(a)
+	if cfg.ValidateRequests || cfg.ValidateResponses {
+		a.Use(routes.ValidateRequests(cfg.ValidateResponses))
+	}
+
+	// Routes.
+	if cfg.LegacyRoutes {
+		routes.LegacyRoutes(a)
+	}
+	routes.DocsRoutes(a)
+	routes.PublicRoutes(a)
+	routes.PrivateRoutes(a)
+	if cfg.GraphQL {
+		routes.GraphQLRoutes(a)
+	}
+	if cfg.GRPC {
+		routes.RPCRoutes(a)
+	}
+	if cfg.Events {
+		routes.EventRoutes(a)
+	}
+	routes.NotFoundRoute(a) // must be registered last
+
+	return a
+}
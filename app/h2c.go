@@ -0,0 +1,172 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+
+	"opendavinci/rpc"
+)
+
+// preface is sent first on HTTP/2 connections without TLS, gRPC clients send it
+// and so does Cloud Run with end-to-end HTTP/2.
+var preface = []byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+
+// sniffTimeout is how long a new connection may take to send its first bytes.
+const sniffTimeout = 10 * time.Second
+
+// NewH2C func for creating the server of HTTP/2 connections without TLS:
+// the gRPC services with server reflection, if set, and all other requests by the Fiber app.
+func NewH2C(a *fiber.App, reflection bool) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &http.Server{
+		Handler:   rpc.NewH2CHandler(reflection, adaptor.FiberApp(a)),
+		Protocols: protocols,
+	}
+}
+
+// splitListener struct to describe a listener passing HTTP/2 connections to h2 and all others to http1,
+// so Fiber and the h2c server share one port.
+type splitListener struct {
+	net.Listener
+	http1, h2 *connQueue
+	closeOnce sync.Once
+}
+
+// splitListen func for listening on addr and splitting connections by protocol.
+func splitListen(addr string) (*splitListener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &splitListener{Listener: ln}
+	l.http1 = newConnQueue(l)
+	l.h2 = newConnQueue(l)
+	go l.serve()
+
+	return l, nil
+}
+
+// serve method for accepting connections until the listener is closed.
+func (l *splitListener) serve() {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				continue
+			}
+			l.close()
+			return
+		}
+		go l.route(conn)
+	}
+}
+
+// route method for passing conn by its first bytes, a mismatch with the preface is HTTP/1.
+func (l *splitListener) route(conn net.Conn) {
+	r := bufio.NewReaderSize(conn, len(preface))
+	_ = conn.SetReadDeadline(time.Now().Add(sniffTimeout))
+
+	// Wait for one more byte at a time, short HTTP/1 requests are not blocked until the timeout.
+	h2 := true
+	for {
+		if _, err := r.Peek(min(r.Buffered()+1, len(preface))); err != nil {
+			conn.Close()
+			return
+		}
+		peeked, _ := r.Peek(min(r.Buffered(), len(preface)))
+		if !bytes.HasPrefix(preface, peeked) {
+			h2 = false
+			break
+		}
+		if len(peeked) == len(preface) {
+			break
+		}
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	queue := l.http1
+	if h2 {
+		queue = l.h2
+	}
+	queue.push(&peekedConn{Conn: conn, r: r})
+}
+
+// close method for closing the listener and both queues.
+func (l *splitListener) close() {
+	l.closeOnce.Do(func() {
+		l.Listener.Close()
+		l.http1.stop()
+		l.h2.stop()
+	})
+}
+
+// connQueue struct to describe the listener of one protocol, closing it closes the shared one.
+type connQueue struct {
+	parent *splitListener
+	conns  chan net.Conn
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newConnQueue(parent *splitListener) *connQueue {
+	return &connQueue{parent: parent, conns: make(chan net.Conn), done: make(chan struct{})}
+}
+
+// push method for passing conn to Accept, it is closed if the queue is.
+func (q *connQueue) push(conn net.Conn) {
+	select {
+	case q.conns <- conn:
+	case <-q.done:
+		conn.Close()
+	}
+}
+
+// Accept method for waiting for the next connection of the protocol.
+func (q *connQueue) Accept() (net.Conn, error) {
+	select {
+	case conn := <-q.conns:
+		return conn, nil
+	case <-q.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close method for stopping to accept connections, new connections of any protocol are refused.
+func (q *connQueue) Close() error {
+	q.parent.close()
+
+	return nil
+}
+
+// stop method for unblocking Accept and push.
+func (q *connQueue) stop() {
+	q.once.Do(func() { close(q.done) })
+}
+
+// Addr method for the address of the shared listener.
+func (q *connQueue) Addr() net.Addr {
+	return q.parent.Addr()
+}
+
+// peekedConn struct to describe a connection whose first bytes were read for routing.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// Read method for reading the peeked bytes first.
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/events"
+)
+
+// Requests counters of the process, reported on shutdown.
+var (
+	inFlight atomic.Int64
+	served   atomic.Int64
+)
+
+// Closer struct to describe a resource released on shutdown,
+// e.g. a worker flushing its queue or the database pool.
+type Closer struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// ShutdownSummary struct to describe how the server was stopped.
+type ShutdownSummary struct {
+	Signal   string
+	Duration time.Duration
+	InFlight int64    // requests in progress when shutdown started
+	Drained  int64    // requests finished before the deadline
+	Aborted  int64    // requests still in progress at the deadline
+	Closed   []string // closers which succeeded
+	Errors   []string
+}
+
+// Err method for getting all shutdown errors as one error.
+func (s *ShutdownSummary) Err() error {
+	if len(s.Errors) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(s.Errors, "; "))
+}
+
+// LogValue method for logging the summary as one group.
+func (s *ShutdownSummary) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("signal", s.Signal),
+		slog.String("duration", s.Duration.Round(time.Millisecond).String()),
+		slog.Int64("inFlight", s.InFlight),
+		slog.Int64("drained", s.Drained),
+		slog.Int64("aborted", s.Aborted),
+		slog.Any("closed", s.Closed),
+		slog.Any("errors", s.Errors),
+	)
+}
+
+// countRequests middleware for tracking requests in progress.
+func countRequests(c *fiber.Ctx) error {
+	inFlight.Add(1)
+	defer func() {
+		inFlight.Add(-1)
+		served.Add(1)
+	}()
+
+	return c.Next()
+}
+
+// Run func for serving until SIGTERM or SIGINT, then shutting down gracefully.
+// Closers are called in order after requests were drained, within the same deadline.
+//
+// If h2c is set, it serves HTTP/2 connections without TLS on the same port, e.g. of gRPC clients,
+// and the Fiber app serves HTTP/1 connections. It is shut down before the closers.
+func Run(a *fiber.App, h2c *http.Server, addr string, timeout time.Duration, closers ...Closer) error {
+	errc := make(chan error, 1)
+	if h2c == nil {
+		go func() {
+			errc <- a.Listen(addr)
+		}()
+	} else {
+		ln, err := splitListen(addr)
+		if err != nil {
+			// Server did not start, e.g. the port is in use.
+			return err
+		}
+		go func() {
+			errc <- a.Listener(ln.http1)
+		}()
+		go func() {
+			// Accept fails once Fiber closed the shared listener on shutdown.
+			if err := h2c.Serve(ln.h2); err != nil && !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
+				slog.Error("h2c server failure", "error", err)
+			}
+		}()
+		closers = append([]Closer{{Name: "h2c server", Close: h2c.Shutdown}}, closers...)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(sig)
+
+	select {
+	case err := <-errc:
+		// Server did not start, e.g. the port is in use.
+		return err
+	case s := <-sig:
+		summary := Shutdown(a, timeout, closers...)
+		summary.Signal = s.String()
+		level := slog.LevelInfo
+		if len(summary.Errors) > 0 {
+			level = slog.LevelError
+		}
+		slog.Log(context.Background(), level, "Server shutdown", "shutdown", summary)
+
+		<-errc // Listen returns when listeners are closed
+		return summary.Err()
+	}
+}
+
+// Shutdown func for stopping the server: new connections are refused,
+// in-flight requests are drained until timeout, then closers are called.
+func Shutdown(a *fiber.App, timeout time.Duration, closers ...Closer) *ShutdownSummary {
+	start := time.Now()
+	summary := &ShutdownSummary{
+		InFlight: inFlight.Load(),
+		Closed:   []string{},
+		Errors:   []string{},
+	}
+	servedBefore := served.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Streams of the change feed never finish, end them so they do not hold the drain.
+	events.Stop()
+
+	if err := a.ShutdownWithContext(ctx); err != nil {
+		summary.Errors = append(summary.Errors, "drain: "+err.Error())
+	}
+	summary.Aborted = inFlight.Load()
+	summary.Drained = min(served.Load()-servedBefore, summary.InFlight)
+
+	for _, c := range closers {
+		if err := c.Close(ctx); err != nil {
+			summary.Errors = append(summary.Errors, c.Name+": "+err.Error())
+			continue
+		}
+		summary.Closed = append(summary.Closed, c.Name)
+	}
+
+	summary.Duration = time.Since(start)
+
+	return summary
+}
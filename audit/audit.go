@@ -0,0 +1,85 @@
+// Package audit describes who made a change, for the append-only audit log.
+// Changes of courses, lessons, enrollments, users and webhooks are recorded by triggers
+// of their tables, see the audit migration. They take the actor of the transaction,
+// which queries set from the context of the request. Auth events and admin actions
+// without a row of their own are recorded by the handlers.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"opendavinci/models"
+)
+
+// Actors without an access token.
+const (
+	Anonymous = "anonymous" // requests without a verified access token
+	System    = "system"    // changes outside of requests, e.g. by jobs or migrations
+)
+
+// Actor struct to describe who made the changes of a request, and from where.
+type Actor struct {
+	ID        string // user:<sub>, token:<hash> of an access token without user, or Anonymous
+	RequestID string
+	IP        string
+}
+
+type actorKey struct{}
+
+// WithActor func for setting the actor of the changes made with ctx.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom func for getting the actor of ctx, if set.
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	if ctx == nil {
+		return Actor{}, false
+	}
+	a, ok := ctx.Value(actorKey{}).(Actor)
+
+	return a, ok
+}
+
+// ActorID func for naming the client of an access token: its user, or the token itself
+// when it has no subject, e.g. an API key of GetNewAccessToken.
+func ActorID(subject, rawToken string) string {
+	if subject != "" {
+		return "user:" + subject
+	}
+
+	return "token:" + TokenID(rawToken)
+}
+
+// TokenID func for identifying an access token without revealing it.
+func TokenID(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// NewEntry func for an entry of an event without a row of its own, e.g. an auth event,
+// made by the actor of ctx. Details of the event are recorded as after.
+func NewEntry(ctx context.Context, action, resourceType, resourceID string, details map[string]any) *models.AuditEntry {
+	a, ok := ActorFrom(ctx)
+	if !ok {
+		a.ID = System
+	}
+
+	e := &models.AuditEntry{
+		Actor:        a.ID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		RequestID:    a.RequestID,
+		IP:           a.IP,
+	}
+	if details != nil {
+		e.After, _ = json.Marshal(details)
+	}
+
+	return e
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"opendavinci/models"
+)
+
+// timeLayout is the format of created in the hashed fields, to_char of audit_append.
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+// Hash func for the hash of entry e, chained to the one of the entry before it.
+// It is computed like audit_append does: SHA-256 of the fields joined by newlines,
+// missing before and after are empty.
+func Hash(e *models.AuditEntry) string {
+	prev := ""
+	if e.PrevHash != nil {
+		prev = *e.PrevHash
+	}
+	before, after := string(e.Before), string(e.After)
+	if before == "null" {
+		before = ""
+	}
+	if after == "null" {
+		after = ""
+	}
+
+	fields := []string{
+		prev,
+		strconv.FormatInt(e.ID, 10),
+		e.Created.UTC().Format(timeLayout),
+		e.Actor,
+		e.Action,
+		e.ResourceType,
+		e.ResourceID,
+		before,
+		after,
+		e.RequestID,
+		e.IP,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Verifier struct to describe a walk along the hash chain, entry by entry in the order of IDs.
+// The zero value starts at the first chained entry, set Head to start after a known one.
+type Verifier struct {
+	Checked int    // chained entries checked
+	Head    string // hash of the last one, keep it elsewhere to detect rewrites of the whole chain
+}
+
+// Check method for verifying, that chained entry e follows the one checked before
+// and was not changed.
+func (v *Verifier) Check(e *models.AuditEntry) error {
+	if e.Hash == nil || e.PrevHash == nil {
+		return fmt.Errorf("entry %d is not chained", e.ID)
+	}
+	if *e.PrevHash != v.Head {
+		return fmt.Errorf("entry %d does not follow the entry before it, entries were removed or reordered", e.ID)
+	}
+	if Hash(e) != *e.Hash {
+		return fmt.Errorf("entry %d was changed", e.ID)
+	}
+
+	v.Checked++
+	v.Head = *e.Hash
+
+	return nil
+}
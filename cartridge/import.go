@@ -0,0 +1,439 @@
+package cartridge
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"opendavinci/models"
+	"opendavinci/render"
+)
+
+// maxFileSize limits the decompressed size of one package file.
+const maxFileSize = 64 << 20
+
+// Package struct to describe a converted package ready for import.
+type Package struct {
+	Course      *models.CatalogCourse
+	Files       []PackageFile
+	Unsupported []Unsupported
+}
+
+// PackageFile struct to describe a package file stored as lesson attachment.
+type PackageFile struct {
+	Key         string
+	ContentType string
+	File        *zip.File
+}
+
+// Open method for reading the package file, limited to protect from zip bombs like readFile.
+// Reading more than the limit fails.
+func (pf PackageFile) Open() (io.ReadCloser, error) {
+	rc, err := pf.File.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &limitedFile{Reader: io.LimitReader(rc, maxFileSize+1), Closer: rc, name: pf.File.Name}, nil
+}
+
+// limitedFile struct to describe a package file failing once more than maxFileSize bytes are read.
+type limitedFile struct {
+	io.Reader
+	io.Closer
+	name string
+	read int64
+}
+
+func (f *limitedFile) Read(p []byte) (int, error) {
+	n, err := f.Reader.Read(p)
+	f.read += int64(n)
+	if f.read > maxFileSize {
+		return n, errors.New(f.name + " is too large")
+	}
+
+	return n, err
+}
+
+// Unsupported struct to describe a package element which was not imported.
+type Unsupported struct {
+	Element    string `json:"element"`
+	Identifier string `json:"identifier,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// converter struct to keep state while converting one package.
+type converter struct {
+	zr       *zip.Reader
+	base     string // directory of imsmanifest.xml
+	manifest *Manifest
+	pkg      *Package
+	used     map[string]bool // referenced resources
+	stored   map[string]bool // blob keys of stored files
+}
+
+// Convert func for converting Common Cartridge or SCORM package to a course.
+// Lessons are the items of the default organization in document order.
+// Set courseID to override the course key taken from the manifest identifier.
+func Convert(zr *zip.Reader, courseID string) (*Package, error) {
+	c := &converter{
+		zr:     zr,
+		pkg:    &Package{Unsupported: []Unsupported{}},
+		used:   map[string]bool{},
+		stored: map[string]bool{},
+	}
+
+	// Manifest is at the root, or in the only top folder of the archive.
+	var manifest *zip.File
+	for _, f := range zr.File {
+		if path.Base(f.Name) == "imsmanifest.xml" && (manifest == nil || len(f.Name) < len(manifest.Name)) {
+			manifest = f
+		}
+	}
+	if manifest == nil {
+		return nil, errors.New("package has no imsmanifest.xml")
+	}
+	c.base = path.Dir(manifest.Name)
+
+	data, err := readFile(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if c.manifest, err = ParseManifest(data); err != nil {
+		return nil, fmt.Errorf("error, not parsed imsmanifest.xml, %w", err)
+	}
+
+	org := c.manifest.Organization()
+	if org == nil {
+		return nil, errors.New("package has no organization")
+	}
+	for _, o := range c.manifest.Organizations.Organizations {
+		if o.Identifier != org.Identifier {
+			c.unsupported("organization", o.Identifier, "only the default organization is imported")
+		}
+	}
+
+	if courseID == "" {
+		courseID = slug(c.manifest.Identifier)
+	}
+	if courseID == "" {
+		return nil, errors.New("course ID is required, manifest has no identifier")
+	}
+
+	title := strings.TrimSpace(org.Title)
+	if title == "" {
+		title = c.manifest.Metadata.Title.Text()
+	}
+	if title == "" {
+		title = courseID
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	c.pkg.Course = &models.CatalogCourse{
+		Course: models.Course{
+			CourseID:     courseID,
+			Title:        truncate(title, 255),
+			Descriptions: truncate(c.manifest.Metadata.Description.Text(), 255),
+			Published:    now,
+			Updated:      now,
+		},
+		Lessons: []models.Lesson{},
+	}
+
+	if err := c.items(org.Items); err != nil {
+		return nil, err
+	}
+
+	// Resources outside of the organization have no place in a course.
+	for _, r := range c.manifest.Resources {
+		if !c.used[r.Identifier] {
+			c.unsupported("resource", r.Identifier, "not referenced by any item of the organization")
+		}
+	}
+
+	return c.pkg, nil
+}
+
+// items converts items with a resource to lessons, folders are flattened.
+func (c *converter) items(items []Item) error {
+	for _, item := range items {
+		if item.Prerequisites != "" || item.Sequencing != nil {
+			c.unsupported("sequencing", item.Identifier, "prerequisites and sequencing rules are ignored")
+		}
+
+		if item.IdentifierRef != "" {
+			if err := c.lesson(item); err != nil {
+				return err
+			}
+		}
+
+		if err := c.items(item.Items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lesson converts one item to a lesson.
+func (c *converter) lesson(item Item) error {
+	res := c.manifest.Resource(item.IdentifierRef)
+	if res == nil {
+		c.unsupported("item", item.Identifier, "references missing resource "+item.IdentifierRef)
+		return nil
+	}
+	c.used[res.Identifier] = true
+
+	course := c.pkg.Course
+	lesson := models.Lesson{
+		LessonID:    course.CourseID + "-" + slug(item.Identifier),
+		Title:       truncate(strings.TrimSpace(item.Title), 255),
+		Format:      render.FormatText,
+		Position:    len(course.Lessons) + 1,
+		Attachments: models.Attachments{},
+	}
+
+	switch {
+	case res.Type == "webcontent" || res.ScormType != "":
+		if res.ScormType == "sco" {
+			c.unsupported("resource", res.Identifier, "SCORM runtime tracking is not supported, content is imported as is")
+		}
+		if err := c.webContent(res, &lesson); err != nil {
+			return err
+		}
+	case strings.HasPrefix(res.Type, "imswl_xmlv1p"):
+		if err := c.webLink(res, &lesson); err != nil {
+			return err
+		}
+	default:
+		c.unsupported("resource", res.Identifier, "resource type "+res.Type+" is not supported")
+		return nil
+	}
+
+	if lesson.Title == "" {
+		lesson.Title = lesson.LessonID
+	}
+	course.Lessons = append(course.Lessons, lesson)
+
+	return nil
+}
+
+// webContent sets the HTML entry point as lesson content, other files become attachments.
+func (c *converter) webContent(res *Resource, lesson *models.Lesson) error {
+	main := res.Href
+	if main == "" && len(res.Files) > 0 {
+		main = res.Files[0].Href
+	}
+
+	if ext := strings.ToLower(path.Ext(main)); ext == ".html" || ext == ".htm" {
+		f := c.file(main)
+		if f == nil {
+			c.unsupported("file", main, "file is missing in package")
+		} else {
+			data, err := readFile(f)
+			if err != nil {
+				return err
+			}
+			lesson.Content = htmlBody(data)
+			lesson.Format = render.FormatHTML
+		}
+	}
+
+	// Files of the resource and of its dependencies are attached to the lesson.
+	files := append([]File(nil), res.Files...)
+	for _, dep := range res.Dependencies {
+		if d := c.manifest.Resource(dep.IdentifierRef); d != nil {
+			c.used[d.Identifier] = true
+			files = append(files, d.Files...)
+		}
+	}
+	for _, file := range files {
+		if file.Href == main && lesson.Format == render.FormatHTML {
+			continue
+		}
+		c.attach(file.Href, lesson)
+	}
+
+	return nil
+}
+
+// webLink sets the URL of Common Cartridge web link as lesson resource URL.
+func (c *converter) webLink(res *Resource, lesson *models.Lesson) error {
+	href := res.Href
+	if href == "" && len(res.Files) > 0 {
+		href = res.Files[0].Href
+	}
+
+	f := c.file(href)
+	if f == nil {
+		c.unsupported("file", href, "web link file is missing in package")
+		return nil
+	}
+
+	data, err := readFile(f)
+	if err != nil {
+		return err
+	}
+
+	link := &WebLink{}
+	if err := xml.Unmarshal(data, link); err != nil {
+		c.unsupported("resource", res.Identifier, "web link is not valid XML")
+		return nil
+	}
+
+	lesson.ResourceURL = truncate(link.URL.Href, 255)
+	if lesson.Title == "" {
+		lesson.Title = truncate(strings.TrimSpace(link.Title), 255)
+	}
+
+	return nil
+}
+
+// attach adds package file as lesson attachment, each file is stored once.
+func (c *converter) attach(href string, lesson *models.Lesson) {
+	f := c.file(href)
+	if f == nil || strings.HasPrefix(path.Clean(href), "..") {
+		c.unsupported("file", href, "file is missing in package")
+		return
+	}
+	if f.UncompressedSize64 > maxFileSize {
+		c.unsupported("file", href, "file is too large")
+		return
+	}
+
+	// Keys depend on course and path only, so importing again overwrites them.
+	key := "packages/" + c.pkg.Course.CourseID + "/" + path.Clean(href)
+
+	contentType := mime.TypeByExtension(path.Ext(href))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	lesson.Attachments = append(lesson.Attachments, models.Attachment{
+		Key:         key,
+		Name:        path.Base(href),
+		ContentType: contentType,
+		Size:        int64(f.UncompressedSize64),
+		Uploaded:    time.Now(),
+	})
+
+	if !c.stored[key] {
+		c.stored[key] = true
+		c.pkg.Files = append(c.pkg.Files, PackageFile{Key: key, ContentType: contentType, File: f})
+	}
+}
+
+// file returns package file by href relative to the manifest.
+func (c *converter) file(href string) *zip.File {
+	if href == "" || strings.Contains(href, "://") {
+		return nil
+	}
+
+	name := path.Clean(path.Join(c.base, href))
+	for _, f := range c.zr.File {
+		if path.Clean(f.Name) == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) unsupported(element, id, reason string) {
+	c.pkg.Unsupported = append(c.pkg.Unsupported, Unsupported{
+		Element:    element,
+		Identifier: id,
+		Reason:     reason,
+	})
+}
+
+// readFile func for reading package file, limited to protect from zip bombs.
+func readFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFileSize {
+		return nil, errors.New(f.Name + " is too large")
+	}
+
+	return data, nil
+}
+
+// htmlBody func for getting the inner HTML of body of an HTML document.
+func htmlBody(data []byte) string {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+
+	var body *html.Node
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.DataAtom == atom.Body {
+			body = n
+			return
+		}
+		for child := n.FirstChild; child != nil && body == nil; child = child.NextSibling {
+			find(child)
+		}
+	}
+	find(doc)
+	if body == nil {
+		return string(data)
+	}
+
+	buf := &bytes.Buffer{}
+	for child := body.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(buf, child); err != nil {
+			return string(data)
+		}
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// slug func for making identifier safe for use in keys.
+func slug(s string) string {
+	b := &strings.Builder{}
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	return strings.Trim(b.String(), "-")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	// Cut on a rune boundary.
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,134 @@
+package cartridge
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Manifest struct to describe imsmanifest.xml of Common Cartridge and SCORM packages.
+// Tags carry no namespaces, so all versions of both specifications are matched.
+type Manifest struct {
+	Identifier    string        `xml:"identifier,attr"`
+	Metadata      Metadata      `xml:"metadata"`
+	Organizations Organizations `xml:"organizations"`
+	Resources     []Resource    `xml:"resources>resource"`
+}
+
+// Metadata struct to describe package schema and LOM metadata.
+type Metadata struct {
+	Schema        string    `xml:"schema"`
+	SchemaVersion string    `xml:"schemaversion"`
+	Title         LOMString `xml:"lom>general>title"`
+	Description   LOMString `xml:"lom>general>description"`
+}
+
+// LOMString struct to describe a LOM text in its CC (string) or SCORM (langstring) form.
+type LOMString struct {
+	Strings     []string `xml:"string"`
+	LangStrings []string `xml:"langstring"`
+}
+
+// Organizations struct to describe the tables of contents of a package.
+type Organizations struct {
+	Default       string         `xml:"default,attr"`
+	Organizations []Organization `xml:"organization"`
+}
+
+// Organization struct to describe one table of contents.
+type Organization struct {
+	Identifier string `xml:"identifier,attr"`
+	Title      string `xml:"title"`
+	Items      []Item `xml:"item"`
+}
+
+// Item struct to describe an entry of a table of contents.
+type Item struct {
+	Identifier    string `xml:"identifier,attr"`
+	IdentifierRef string `xml:"identifierref,attr"`
+	Title         string `xml:"title"`
+	Items         []Item `xml:"item"`
+
+	// SCORM sequencing is not supported, it is only detected for the report.
+	Prerequisites string    `xml:"prerequisites"`
+	Sequencing    *struct{} `xml:"sequencing"`
+}
+
+// Resource struct to describe a resource referenced by items.
+type Resource struct {
+	Identifier   string       `xml:"identifier,attr"`
+	Type         string       `xml:"type,attr"`
+	Href         string       `xml:"href,attr"`
+	ScormType    string       `xml:"scormType,attr"`
+	Files        []File       `xml:"file"`
+	Dependencies []Dependency `xml:"dependency"`
+}
+
+// File struct to describe a file of a resource.
+type File struct {
+	Href string `xml:"href,attr"`
+}
+
+// Dependency struct to describe a resource needed by another resource.
+type Dependency struct {
+	IdentifierRef string `xml:"identifierref,attr"`
+}
+
+// WebLink struct to describe the XML document of a Common Cartridge web link.
+type WebLink struct {
+	Title string `xml:"title"`
+	URL   struct {
+		Href string `xml:"href,attr"`
+	} `xml:"url"`
+}
+
+// ParseManifest func for decoding imsmanifest.xml.
+func ParseManifest(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	if err := xml.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Organization method for getting the default organization of package.
+func (m *Manifest) Organization() *Organization {
+	orgs := m.Organizations.Organizations
+	for i := range orgs {
+		if orgs[i].Identifier == m.Organizations.Default {
+			return &orgs[i]
+		}
+	}
+	if len(orgs) > 0 {
+		return &orgs[0]
+	}
+
+	return nil
+}
+
+// Resource method for getting resource by given identifier.
+func (m *Manifest) Resource(id string) *Resource {
+	for i := range m.Resources {
+		if m.Resources[i].Identifier == id {
+			return &m.Resources[i]
+		}
+	}
+
+	return nil
+}
+
+// IsSCORM method for checking, if package is a SCORM package.
+func (m *Manifest) IsSCORM() bool {
+	return strings.Contains(strings.ToUpper(m.Metadata.Schema), "SCORM")
+}
+
+// Text method for getting the first non-empty text.
+func (s LOMString) Text() string {
+	for _, v := range append(s.Strings, s.LangStrings...) {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
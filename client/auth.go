@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"opendavinci/models"
+)
+
+// refreshBefore is how long before its expiration a token is refreshed,
+// so it does not expire between sending and checking the request.
+const refreshBefore = 30 * time.Second
+
+// errNoToken is returned, if Refresh does not get a token.
+var errNoToken = errors.New("client: refreshed access token is empty")
+
+// headerAPIKey is the header of the API key, a token of its user is issued.
+const headerAPIKey = "X-API-Key"
+
+// NewAccessToken method for getting a new access token of the API, of the user of APIKey, if it is set.
+// It is the default Refresh of clients, the client keeps using the token it returns.
+func (c *Client) NewAccessToken(ctx context.Context) (string, error) {
+	req := &request{method: http.MethodGet, path: "/token/new"}
+	if c.cfg.APIKey != "" {
+		req.header = http.Header{headerAPIKey: {c.cfg.APIKey}}
+	}
+
+	var resp models.TokenResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.AccessToken, nil
+}
+
+// accessToken method for getting the token of private routes, refreshed if it expires soon.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.expires.IsZero() || time.Until(c.expires) > refreshBefore) {
+		return c.token, nil
+	}
+
+	// Concurrent requests wait for the one refresh while holding the lock.
+	token, err := c.cfg.Refresh(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", errNoToken
+	}
+	c.token, c.expires = token, expiration(token)
+
+	return c.token, nil
+}
+
+// setToken method for replacing the access token, the empty token is refreshed on next use.
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token, c.expires = token, expiration(token)
+}
+
+// expiration func for reading the exp claim of a JWT without verifying it,
+// the server verifies the token, the client only needs to know when to refresh.
+func expiration(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(claims.Exp), 0)
+}
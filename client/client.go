@@ -0,0 +1,234 @@
+// Package client is a typed Go client of the v1 API for other services.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config struct to describe the API and how the client calls it.
+type Config struct {
+	BaseURL    string       // e.g. https://courses.example.com, without /api/v1
+	HTTPClient *http.Client // default has a timeout of 30 seconds
+	UserAgent  string
+
+	// Token is the access token of private routes, it is refreshed when it expires.
+	// Refresh gets a new token, default is a new token from the API,
+	// of the user of APIKey, if it is set.
+	Token   string
+	Refresh func(ctx context.Context) (string, error)
+	APIKey  string
+
+	// Requests answered with 429 or 503 are retried after Retry-After,
+	// or after an exponential backoff from MinBackoff to MaxBackoff.
+	MaxRetries int // default is 3, negative disables retries
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Client struct to describe a client of the API, it is safe for concurrent use.
+type Client struct {
+	cfg  Config
+	base *url.URL
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time // zero, if the token has no expiration
+}
+
+// New func for creating a client of the API at cfg.BaseURL.
+func New(cfg Config) (*Client, error) {
+	base, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("client: base URL %q is not http or https", cfg.BaseURL)
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = "opendavinci-client"
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = 500 * time.Millisecond
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 30 * time.Second
+	}
+
+	c := &Client{cfg: cfg, base: base}
+	if cfg.Refresh == nil {
+		c.cfg.Refresh = c.NewAccessToken
+	}
+	c.setToken(cfg.Token)
+
+	return c, nil
+}
+
+// request struct to describe a call of the API, body is sent again on retries.
+type request struct {
+	method      string
+	path        string // below /api/v1
+	query       url.Values
+	contentType string
+	body        []byte
+	header      http.Header
+	private     bool // send the access token
+}
+
+// jsonRequest func for a request with v marshaled as JSON body.
+func jsonRequest(method, path string, v any, private bool) (*request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("client: encode request: %w", err)
+	}
+
+	return &request{method: method, path: path, contentType: "application/json", body: body, private: private}, nil
+}
+
+// do method for sending req and decoding the JSON response into v, v may be nil.
+// Responses of 429 and 503 are retried, a rejected token is refreshed once.
+func (c *Client) do(ctx context.Context, req *request, v any) error {
+	refreshed, retries := false, 0
+	for {
+		resp, err := c.send(ctx, req)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("client: read response: %w", err)
+		}
+
+		if resp.StatusCode < http.StatusBadRequest {
+			if v == nil || len(body) == 0 {
+				return nil
+			}
+			if err := json.Unmarshal(body, v); err != nil {
+				return fmt.Errorf("client: decode response: %w", err)
+			}
+			return nil
+		}
+
+		apiErr := decodeError(resp, body)
+		switch {
+		case req.private && !refreshed && resp.StatusCode == http.StatusUnauthorized:
+			// The token expired or was revoked before its expiration.
+			refreshed = true
+			c.setToken("")
+			continue
+		case retryable(resp.StatusCode) && retries < c.cfg.MaxRetries:
+			if err := sleep(ctx, c.backoff(retries, apiErr.RetryAfter)); err != nil {
+				return err
+			}
+			retries++
+			continue
+		}
+
+		return apiErr
+	}
+}
+
+// send method for sending one attempt of req.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	u := c.base.JoinPath("/api/v1", req.path)
+	u.RawQuery = req.query.Encode()
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	hr, err := http.NewRequestWithContext(ctx, req.method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	hr.Header.Set("Accept", "application/json, application/problem+json")
+	hr.Header.Set("User-Agent", c.cfg.UserAgent)
+	if req.contentType != "" {
+		hr.Header.Set("Content-Type", req.contentType)
+	}
+	for key, values := range req.header {
+		hr.Header[key] = values
+	}
+
+	if req.private {
+		token, err := c.accessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hr.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.cfg.HTTPClient.Do(hr)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", req.method, u.Path, err)
+	}
+
+	return resp, nil
+}
+
+// retryable func for statuses which are worth sending again, the request was not processed.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// backoff method for the delay before the retry after attempt,
+// the server's Retry-After wins over the exponential backoff with jitter.
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, c.cfg.MaxBackoff)
+	}
+
+	d := c.cfg.MinBackoff << attempt
+	if d <= 0 || d > c.cfg.MaxBackoff {
+		d = c.cfg.MaxBackoff
+	}
+
+	// Jitter spreads the retries of many clients, each waits at least half of d.
+	return d/2 + rand.N(d/2+1)
+}
+
+// sleep func for waiting d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// parseRetryAfter func for the delay of a Retry-After header in seconds or as HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+
+	return 0
+}
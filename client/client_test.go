@@ -0,0 +1,259 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"opendavinci/app"
+	"opendavinci/client"
+	"opendavinci/config"
+	"opendavinci/models"
+)
+
+// newClient func for a client of srv with short backoffs.
+func newClient(t *testing.T, srv *httptest.Server, cfg client.Config) *client.Client {
+	t.Helper()
+
+	cfg.BaseURL = srv.URL
+	cfg.MinBackoff, cfg.MaxBackoff = time.Millisecond, 10*time.Millisecond
+	c, err := client.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// writeProblem func for an error response like the ones of the API.
+func writeProblem(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"status": status, "code": code, "title": http.StatusText(status)})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestRefreshOnUnauthorized(t *testing.T) {
+	var issued atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/token/new", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "key" {
+			t.Errorf("X-API-Key = %q, want key", r.Header.Get("X-API-Key"))
+		}
+		writeJSON(w, models.TokenResponse{AccessToken: "token-" + strconv.Itoa(int(issued.Add(1)))})
+	})
+	mux.HandleFunc("GET /api/v1/user/me", func(w http.ResponseWriter, r *http.Request) {
+		// The first token was revoked before its expiration.
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			writeProblem(w, http.StatusUnauthorized, "auth.token_invalid")
+			return
+		}
+		writeJSON(w, models.UserResponse{User: models.User{Email: "a@example.com"}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newClient(t, srv, client.Config{APIKey: "key"})
+	user, err := c.CurrentUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "a@example.com" {
+		t.Errorf("email = %q, want a@example.com", user.Email)
+	}
+	if n := issued.Load(); n != 2 {
+		t.Errorf("%d tokens issued, want 2", n)
+	}
+}
+
+func TestRefreshOnce(t *testing.T) {
+	var issued atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/token/new", func(w http.ResponseWriter, r *http.Request) {
+		issued.Add(1)
+		writeJSON(w, models.TokenResponse{AccessToken: "token"})
+	})
+	mux.HandleFunc("GET /api/v1/user/me", func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, http.StatusUnauthorized, "auth.token_invalid")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := newClient(t, srv, client.Config{})
+	_, err := c.CurrentUser(context.Background())
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+	if n := issued.Load(); n != 2 {
+		t.Errorf("%d tokens issued, want 2", n)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	id := uuid.New()
+	statuses := []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(int(attempts.Add(1)), len(statuses))-1]
+		if status != http.StatusOK {
+			w.Header().Set("Retry-After", "0")
+			writeProblem(w, status, "rate_limit.exceeded")
+			return
+		}
+		writeJSON(w, models.CourseResponse{Course: models.Course{ID: id}})
+	}))
+	defer srv.Close()
+
+	c := newClient(t, srv, client.Config{})
+	course, err := c.Course(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if course.ID != id {
+		t.Errorf("course ID = %s, want %s", course.ID, id)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("%d attempts, want 3", n)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "1")
+		writeProblem(w, http.StatusTooManyRequests, "rate_limit.exceeded")
+	}))
+	defer srv.Close()
+
+	// Retry-After is capped by MaxBackoff.
+	c := newClient(t, srv, client.Config{MaxRetries: 2})
+	_, err := c.Course(context.Background(), uuid.New())
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	if apiErr.Code != "rate_limit.exceeded" || apiErr.RetryAfter != time.Second {
+		t.Errorf("code = %q, retry after = %s", apiErr.Code, apiErr.RetryAfter)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("%d attempts, want 3", n)
+	}
+}
+
+func TestCourses(t *testing.T) {
+	courses := make([]models.Course, 5)
+	for i := range courses {
+		courses[i].ID = uuid.New()
+	}
+	var pages atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages.Add(1)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		end := min(start+limit, len(courses))
+
+		resp := models.CoursesResponse{Courses: courses[start:end], Count: end - start}
+		if end < len(courses) {
+			resp.NextCursor = strconv.Itoa(end)
+		}
+		writeJSON(w, resp)
+	}))
+	defer srv.Close()
+
+	c := newClient(t, srv, client.Config{})
+	var got []uuid.UUID
+	for course, err := range c.Courses(context.Background(), 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, course.ID)
+	}
+	if len(got) != len(courses) {
+		t.Fatalf("%d courses, want %d", len(got), len(courses))
+	}
+	for i := range got {
+		if got[i] != courses[i].ID {
+			t.Errorf("course %d = %s, want %s", i, got[i], courses[i].ID)
+		}
+	}
+	if n := pages.Load(); n != 3 {
+		t.Errorf("%d pages, want 3", n)
+	}
+
+	// Breaking the loop gets no further pages.
+	pages.Store(0)
+	for range c.Courses(context.Background(), 2) {
+		break
+	}
+	if n := pages.Load(); n != 1 {
+		t.Errorf("%d pages after break, want 1", n)
+	}
+}
+
+func TestCoursesError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, http.StatusBadRequest, "request.invalid_query")
+	}))
+	defer srv.Close()
+
+	c := newClient(t, srv, client.Config{})
+	n := 0
+	for _, err := range c.Courses(context.Background(), 2) {
+		n++
+		if !errors.Is(err, &client.Error{Code: "request.invalid_query"}) {
+			t.Errorf("err = %v, want request.invalid_query", err)
+		}
+	}
+	if n != 1 {
+		t.Errorf("%d iterations, want 1", n)
+	}
+}
+
+// TestServer calls the app in-process, on routes which do not need the database.
+func TestServer(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "secret")
+	t.Setenv("DB_SERVER_URL", "postgres://test@127.0.0.1:1/test")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Set(cfg)
+
+	a := app.New(app.NewConfig(cfg))
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go a.Listener(ln)
+	defer a.Shutdown()
+
+	c, err := client.New(client.Config{BaseURL: "http://" + ln.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The anonymous token of the default refresh has no user.
+	_, err = c.User(context.Background(), uuid.New())
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, client.ErrForbidden) {
+		t.Fatalf("err = %v, want ErrForbidden", err)
+	}
+	if apiErr.Code != "auth.forbidden" || apiErr.RequestID == "" {
+		t.Errorf("code = %q, request ID = %q", apiErr.Code, apiErr.RequestID)
+	}
+}
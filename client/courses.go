@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"opendavinci/models"
+)
+
+// CoursesPage method for getting one page of courses ordered by creation,
+// cursor is empty for the first page and NextCursor of the previous page after it.
+func (c *Client) CoursesPage(ctx context.Context, limit int, cursor string) (*models.CoursesResponse, error) {
+	query := url.Values{"limit": {strconv.Itoa(limit)}}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var resp models.CoursesResponse
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/courses", query: query}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Courses method for iterating all courses, pages of pageSize courses are got while iterating.
+// Iteration stops after the first error, e.g.
+//
+//	for course, err := range c.Courses(ctx, 50) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) Courses(ctx context.Context, pageSize int) iter.Seq2[models.Course, error] {
+	return func(yield func(models.Course, error) bool) {
+		cursor := ""
+		for {
+			page, err := c.CoursesPage(ctx, pageSize, cursor)
+			if err != nil {
+				yield(models.Course{}, err)
+				return
+			}
+			for _, course := range page.Courses {
+				if !yield(course, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// Course method for getting one course by ID.
+func (c *Client) Course(ctx context.Context, id uuid.UUID) (*models.Course, error) {
+	var resp models.CourseResponse
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/course/" + id.String()}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Course, nil
+}
+
+// CreateCourse method for creating a course, the created course has its ID.
+func (c *Client) CreateCourse(ctx context.Context, course *models.Course) (*models.Course, error) {
+	req, err := jsonRequest(http.MethodPost, "/course", course, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.CourseResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Course, nil
+}
+
+// UpdateCourse method for updating the course with the ID of course.
+func (c *Client) UpdateCourse(ctx context.Context, course *models.Course) error {
+	req, err := jsonRequest(http.MethodPut, "/course", course, true)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
+// UploadCourseImage method for uploading the image of a course, name is the file name.
+func (c *Client) UploadCourseImage(ctx context.Context, id uuid.UUID, name string, image io.Reader) (*models.Course, error) {
+	req, err := fileRequest("/course/"+id.String()+"/image", "image", name, image)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.CourseResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Course, nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Error struct to describe an error response of the API, decoded from the
+// application/problem+json body, or the legacy error envelope of older servers.
+// Code is one of the stable codes of the problem package, e.g. course.not_found,
+// it is empty for errors which are not sent by the API, e.g. of a proxy.
+type Error struct {
+	Status    int
+	Code      string
+	Title     string
+	Detail    string
+	Instance  string
+	RequestID string
+	Errors    map[string]string // invalid fields of validation.failed
+
+	RetryAfter time.Duration // of 429 and 503 responses
+}
+
+// Errors of the API by status, match them with errors.Is, e.g. errors.Is(err, client.ErrNotFound).
+var (
+	ErrBadRequest   = &Error{Status: http.StatusBadRequest}
+	ErrUnauthorized = &Error{Status: http.StatusUnauthorized}
+	ErrForbidden    = &Error{Status: http.StatusForbidden}
+	ErrNotFound     = &Error{Status: http.StatusNotFound}
+	ErrConflict     = &Error{Status: http.StatusConflict}
+	ErrRateLimited  = &Error{Status: http.StatusTooManyRequests}
+	ErrUnavailable  = &Error{Status: http.StatusServiceUnavailable}
+)
+
+// Error method for describing the error like the server logs it.
+func (e *Error) Error() string {
+	name := e.Code
+	if name == "" {
+		name = e.Title
+	}
+	msg := fmt.Sprintf("client: %s (%d)", name, e.Status)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+
+	return msg
+}
+
+// Is method for matching the errors by status, and errors with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Code != "" {
+		return t.Code == e.Code
+	}
+
+	return t.Status == e.Status
+}
+
+// legacyError struct to describe the error envelope of servers with SERVER_ERROR_FORMAT=legacy.
+type legacyError struct {
+	Error     bool            `json:"error"`
+	Msg       json.RawMessage `json:"msg"` // detail, or the invalid fields
+	Code      string          `json:"code"`
+	RequestID string          `json:"requestId"`
+}
+
+// decodeError func for the error of a response with status 400 or above.
+func decodeError(resp *http.Response, body []byte) *Error {
+	e := &Error{
+		Status:     resp.StatusCode,
+		Title:      http.StatusText(resp.StatusCode),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/problem+json":
+		var p struct {
+			Title     string            `json:"title"`
+			Detail    string            `json:"detail"`
+			Instance  string            `json:"instance"`
+			Code      string            `json:"code"`
+			RequestID string            `json:"requestId"`
+			Errors    map[string]string `json:"errors"`
+		}
+		if json.Unmarshal(body, &p) == nil {
+			e.Code, e.Title, e.Detail, e.Instance, e.Errors = p.Code, p.Title, p.Detail, p.Instance, p.Errors
+			if p.RequestID != "" {
+				e.RequestID = p.RequestID
+			}
+		}
+	case mediaType == "application/json":
+		var l legacyError
+		if json.Unmarshal(body, &l) == nil && l.Error {
+			e.Code = l.Code
+			if l.RequestID != "" {
+				e.RequestID = l.RequestID
+			}
+			if json.Unmarshal(l.Msg, &e.Detail) != nil {
+				_ = json.Unmarshal(l.Msg, &e.Errors)
+			}
+		}
+	default:
+		// Errors of proxies and load balancers, e.g. an HTML page.
+		e.Detail = strings.TrimSpace(string(body[:min(len(body), 200)]))
+	}
+
+	return e
+}
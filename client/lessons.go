@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"opendavinci/models"
+	"opendavinci/render"
+)
+
+// Lesson method for getting one lesson by ID.
+func (c *Client) Lesson(ctx context.Context, id uuid.UUID) (*models.Lesson, error) {
+	var resp models.LessonResponse
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/lesson/" + id.String()}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Lesson, nil
+}
+
+// LessonHTML method for getting the lesson content rendered to sanitized HTML.
+func (c *Client) LessonHTML(ctx context.Context, id uuid.UUID) (*render.Document, error) {
+	var resp models.DocumentResponse
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/lesson/" + id.String() + "/html"}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Document, nil
+}
+
+// UploadLessonAttachment method for uploading a file of a lesson, name is the file name.
+func (c *Client) UploadLessonAttachment(ctx context.Context, id uuid.UUID, name string, file io.Reader) (*models.Attachment, error) {
+	req, err := fileRequest("/lesson/"+id.String()+"/attachment", "file", name, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.AttachmentResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Attachment, nil
+}
+
+// fileRequest func for a private POST of a multipart body with the file in field.
+// The file is read into memory to send it again on retries.
+func fileRequest(path, field, name string, file io.Reader) (*request, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	part, err := w.CreateFormFile(field, name)
+	if err != nil {
+		return nil, fmt.Errorf("client: encode request: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("client: read %s: %w", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("client: encode request: %w", err)
+	}
+
+	return &request{
+		method:      http.MethodPost,
+		path:        path,
+		contentType: w.FormDataContentType(),
+		body:        body.Bytes(),
+		private:     true,
+	}, nil
+}
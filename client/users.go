@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"opendavinci/models"
+)
+
+// CurrentUser method for getting the user of the access token, e.g. of the token issued for APIKey.
+func (c *Client) CurrentUser(ctx context.Context) (*models.User, error) {
+	var resp models.UserResponse
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/user/me", private: true}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
+
+// User method for getting one user by ID, the token must be of the user or an admin.
+func (c *Client) User(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var resp models.UserResponse
+	if err := c.do(ctx, &request{method: http.MethodGet, path: "/user/" + id.String(), private: true}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.User, nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import "time"
+
+// Config struct to describe all settings of the application.
+// Every field is read from the env var named in its env tag.
+type Config struct {
+	Server      Server
+	JWT         JWT
+	DB          DB
+	Media       Media
+	Metrics     Metrics
+	Tracing     Tracing
+	Logging     Logging
+	RateLimit   RateLimit
+	GraphQL     GraphQL
+	GRPC        GRPC
+	Events      Events
+	Webhooks    Webhooks
+	Jobs        Jobs
+	Idempotency Idempotency
+	Audit       Audit
+}
+
+// Server struct to describe HTTP server settings.
+type Server struct {
+	Port              int           `env:"PORT" default:"8080" validate:"min=1,max=65535"` // set by Cloud Run
+	URL               string        `env:"SERVER_URL"`                                     // host:port, default is :PORT
+	ReadTimeout       time.Duration `env:"SERVER_READ_TIMEOUT" unit:"s" validate:"min=0"`
+	BodyLimitMB       int           `env:"SERVER_BODY_LIMIT_MB" default:"50" validate:"min=1"`
+	LegacyRoutes      bool          `env:"SERVER_LEGACY_ROUTES"`                                                  // old clients opt in to the pre-v1 API
+	ErrorFormat       string        `env:"SERVER_ERROR_FORMAT" default:"problem" validate:"oneof=problem legacy"` // legacy keeps the pre-7807 envelope
+	ValidateRequests  bool          `env:"SERVER_VALIDATE_REQUESTS" default:"true"`                               // reject requests which do not match the OpenAPI document
+	ValidateResponses bool          `env:"SERVER_VALIDATE_RESPONSES"`                                             // development mode, responses which do not match fail with 500
+	ShutdownTimeout   time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" unit:"s" default:"8" validate:"min=0"`         // Cloud Run kills 10s after SIGTERM
+}
+
+// JWT struct to describe access token settings.
+type JWT struct {
+	SecretKey     string        `env:"JWT_SECRET_KEY" secret:"true" validate:"required"`
+	ExpireMinutes time.Duration `env:"JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT" unit:"m" default:"15" validate:"min=1m"`
+}
+
+// DB struct to describe database connection settings.
+type DB struct {
+	ServerURL          string        `env:"DB_SERVER_URL" secret:"true" validate:"required"`
+	MaxConnections     int           `env:"DB_MAX_CONNECTIONS" default:"10" validate:"min=0"`
+	MaxIdleConnections int           `env:"DB_MAX_IDLE_CONNECTIONS" default:"2" validate:"min=0"`
+	MaxLifetime        time.Duration `env:"DB_MAX_LIFETIME_CONNECTIONS" unit:"s" validate:"min=0"`
+}
+
+// Media struct to describe blob store and upload settings.
+type Media struct {
+	Store               string        `env:"MEDIA_STORE" default:"local" validate:"oneof=local gcs"`
+	LocalDir            string        `env:"MEDIA_LOCAL_DIR" default:"uploads" validate:"required"`
+	BaseURL             string        `env:"MEDIA_BASE_URL" default:"/api/v1/media" validate:"required"`
+	GCSBucket           string        `env:"MEDIA_GCS_BUCKET" validate:"required_if=Store gcs"`
+	URLSecret           string        `env:"MEDIA_URL_SECRET" secret:"true"` // default is derived from JWT_SECRET_KEY
+	URLExpire           time.Duration `env:"MEDIA_URL_EXPIRE_MINUTES" unit:"m" default:"15" validate:"min=1m"`
+	MaxImageSizeMB      int           `env:"MEDIA_MAX_IMAGE_SIZE_MB" default:"2" validate:"min=1"`
+	MaxAttachmentSizeMB int           `env:"MEDIA_MAX_ATTACHMENT_SIZE_MB" default:"10" validate:"min=1"`
+	MaxPackageSizeMB    int           `env:"MEDIA_MAX_PACKAGE_SIZE_MB" default:"50" validate:"min=1"`
+	VariantsLazy        bool          `env:"MEDIA_VARIANTS_LAZY"`
+	VariantFormat       string        `env:"MEDIA_VARIANT_FORMAT" default:"jpeg" validate:"oneof=jpeg webp"`
+}
+
+// Metrics struct to describe Prometheus metrics settings.
+// Metrics are off by default, enabled ones are served on the admin port, or at /metrics of the API port without it.
+type Metrics struct {
+	Enabled bool   `env:"METRICS_ENABLED"`
+	Addr    string `env:"METRICS_ADDR" validate:"omitempty,hostname_port"` // admin port, keeps metrics off the public API port
+}
+
+// Tracing struct to describe OpenTelemetry tracing settings.
+// The OTLP exporter reads OTEL_EXPORTER_OTLP_ENDPOINT and other OTEL_* env vars itself.
+type Tracing struct {
+	Exporter      string `env:"TRACING_EXPORTER" default:"none" validate:"oneof=none otlp"` // none keeps spans for log correlation only
+	ServiceName   string `env:"OTEL_SERVICE_NAME" default:"opendavinci"`
+	SamplePercent int    `env:"TRACING_SAMPLE_PERCENT" default:"100" validate:"min=0,max=100"`
+}
+
+// Logging struct to describe log settings.
+type Logging struct {
+	Level     string `env:"LOG_LEVEL" default:"info" validate:"oneof=debug info warn error"`
+	Format    string `env:"LOG_FORMAT" default:"json" validate:"oneof=json text"` // json for Cloud Logging
+	ProjectID string `env:"GOOGLE_CLOUD_PROJECT"`                                 // links log entries to Cloud Trace
+}
+
+// RateLimit struct to describe request limits, rates are requests per minute of one client.
+// Anonymous requests are limited by IP, authenticated ones by user. Requests with tokens without user
+// are limited by IP, with the anonymous limit as well.
+type RateLimit struct {
+	Enabled        bool   `env:"RATELIMIT_ENABLED" default:"true"`
+	Store          string `env:"RATELIMIT_STORE" default:"memory" validate:"oneof=memory postgres"` // postgres shares limits across instances
+	Anonymous      int    `env:"RATELIMIT_ANONYMOUS_PER_MINUTE" default:"120" validate:"min=1"`
+	AnonymousBurst int    `env:"RATELIMIT_ANONYMOUS_BURST" default:"60" validate:"min=1"`
+	User           int    `env:"RATELIMIT_USER_PER_MINUTE" default:"60" validate:"min=1"`
+	UserBurst      int    `env:"RATELIMIT_USER_BURST" default:"30" validate:"min=1"`
+	Token          int    `env:"RATELIMIT_TOKEN_PER_MINUTE" default:"5" validate:"min=1"` // issuing access tokens
+	TokenBurst     int    `env:"RATELIMIT_TOKEN_BURST" default:"5" validate:"min=1"`
+	ProxyHops      int    `env:"RATELIMIT_PROXY_HOPS" default:"1" validate:"min=0"` // proxies appending to X-Forwarded-For, 1 on Cloud Run, 0 without proxy
+}
+
+// GraphQL struct to describe the /graphql endpoint.
+type GraphQL struct {
+	Enabled       bool `env:"GRAPHQL_ENABLED" default:"true"`
+	MaxDepth      int  `env:"GRAPHQL_MAX_DEPTH" default:"8" validate:"min=1"`         // nesting of fields
+	MaxComplexity int  `env:"GRAPHQL_MAX_COMPLEXITY" default:"2000" validate:"min=1"` // fields, list fields count per item
+}
+
+// GRPC struct to describe CourseService and LessonService of proto/opendavinci/v1.
+// gRPC is served on the API port over HTTP/2 without TLS (h2c), Connect and gRPC-Web over HTTP/1.1 as well.
+type GRPC struct {
+	Enabled    bool `env:"GRPC_ENABLED" default:"true"`
+	Reflection bool `env:"GRPC_REFLECTION" default:"true"` // lists the services for tools like grpcurl
+}
+
+// Events struct to describe the change feed of GET /api/v1/events.
+type Events struct {
+	Enabled        bool          `env:"EVENTS_ENABLED" default:"true"`
+	Source         string        `env:"EVENTS_SOURCE" default:"listen" validate:"oneof=listen poll"` // poll reads the table of Postgres without LISTEN, e.g. behind a pooler
+	PollInterval   time.Duration `env:"EVENTS_POLL_INTERVAL" unit:"s" default:"2" validate:"min=100ms"`
+	Heartbeat      time.Duration `env:"EVENTS_HEARTBEAT" unit:"s" default:"15" validate:"min=1s"` // comment sent to keep idle streams open
+	Retention      time.Duration `env:"EVENTS_RETENTION" default:"24h" validate:"min=1m"`         // how long streams can resume with Last-Event-ID
+	MaxSubscribers int           `env:"EVENTS_MAX_SUBSCRIBERS" default:"1000" validate:"min=1"`   // streams of one instance
+}
+
+// Webhooks struct to describe deliveries of events to webhook subscriptions, attempted by webhook.deliver jobs.
+// A failed attempt is retried after RetryMin, doubled after each failure up to RetryMax.
+type Webhooks struct {
+	Enabled      bool          `env:"WEBHOOKS_ENABLED" default:"true"`                          // workers of this instance deliver
+	Timeout      time.Duration `env:"WEBHOOKS_TIMEOUT" unit:"s" default:"10" validate:"min=1s"` // of one attempt
+	MaxAttempts  int           `env:"WEBHOOKS_MAX_ATTEMPTS" default:"10" validate:"min=1"`
+	RetryMin     time.Duration `env:"WEBHOOKS_RETRY_MIN" default:"30s" validate:"min=1s"`
+	RetryMax     time.Duration `env:"WEBHOOKS_RETRY_MAX" default:"6h" validate:"gtefield=RetryMin"`
+	Retention    time.Duration `env:"WEBHOOKS_RETENTION" default:"720h" validate:"min=1h"` // of the delivery log
+	AllowPrivate bool          `env:"WEBHOOKS_ALLOW_PRIVATE"`                              // development only: deliver to loopback and private addresses
+}
+
+// Jobs struct to describe the background job queue and its worker.
+// The worker runs next to the API with mode server, or alone with mode worker, e.g. as a separate service.
+type Jobs struct {
+	Mode         string        `env:"JOBS_MODE" default:"server" validate:"oneof=server api worker"`
+	Concurrency  int           `env:"JOBS_CONCURRENCY" default:"4" validate:"min=1"` // jobs in progress of one worker
+	PollInterval time.Duration `env:"JOBS_POLL_INTERVAL" unit:"s" default:"2" validate:"min=100ms"`
+	Lease        time.Duration `env:"JOBS_LEASE" default:"5m" validate:"min=10s"`     // running jobs of stopped workers are claimed again after it
+	MaxAttempts  int           `env:"JOBS_MAX_ATTEMPTS" default:"5" validate:"min=1"` // default of job kinds
+	RetryMin     time.Duration `env:"JOBS_RETRY_MIN" default:"10s" validate:"min=1s"`
+	RetryMax     time.Duration `env:"JOBS_RETRY_MAX" default:"1h" validate:"gtefield=RetryMin"`
+	Retention    time.Duration `env:"JOBS_RETENTION" default:"168h" validate:"min=1h"` // of done jobs, dead ones are kept
+}
+
+// Idempotency struct to describe replays of POST requests with an Idempotency-Key header.
+// The first response is stored per key and user and replayed to retries until TTL.
+type Idempotency struct {
+	Enabled bool          `env:"IDEMPOTENCY_ENABLED" default:"true"`
+	TTL     time.Duration `env:"IDEMPOTENCY_TTL" default:"24h" validate:"min=1m"`
+	Lease   time.Duration `env:"IDEMPOTENCY_LEASE" default:"5m" validate:"min=1s"` // retries take over the key of a request in progress after it
+}
+
+// Audit struct to describe the audit log of changes and auth events.
+// With HashChain every entry carries the hash of the one before it, so changes of the log are detected.
+type Audit struct {
+	HashChain bool `env:"AUDIT_HASH_CHAIN"` // entries are appended one at a time, until their transaction commits
+}
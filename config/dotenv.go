@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readDotEnv func for reading KEY=VALUE lines of a .env file.
+// Comments, blank lines, "export " prefixes and quoted values are supported.
+func readDotEnv(name string) (map[string]string, error) {
+	if name == "" {
+		name = ".env"
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return values, fmt.Errorf("%s:%d: expected KEY=VALUE", name, n)
+		}
+
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return values, fmt.Errorf("%s:%d: %v", name, n, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) > 1:
+			value = value[1 : len(value)-1]
+		default:
+			// Strip trailing comment of unquoted value.
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+
+		values[key] = value
+	}
+
+	return values, scanner.Err()
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	mu      sync.Mutex
+	current *Config
+)
+
+// Errors type to describe every invalid setting found while loading.
+type Errors []string
+
+// Error method for listing all invalid settings, one per line.
+func (e Errors) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// Load func for reading settings and keeping them for Get.
+//
+// Every setting is looked up in this order:
+//   - env var, e.g. JWT_SECRET_KEY;
+//   - file named by the env var with _FILE suffix, e.g. JWT_SECRET_KEY_FILE;
+//   - file named like the env var in CONFIG_SECRETS_DIR (mounted secrets);
+//   - .env file, or the file in CONFIG_ENV_FILE;
+//   - default value.
+//
+// It returns Errors with every invalid setting, so startup can fail fast.
+func Load() (*Config, error) {
+	cfg, err := load()
+
+	mu.Lock()
+	current = cfg
+	mu.Unlock()
+
+	return cfg, err
+}
+
+// Get func for getting the loaded settings.
+// Settings are loaded on first use, if Load was not called, e.g. in tests.
+func Get() *Config {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if current == nil {
+		current, _ = load()
+	}
+
+	return current
+}
+
+// Set func for replacing the loaded settings, e.g. in tests.
+func Set(cfg *Config) {
+	mu.Lock()
+	current = cfg
+	mu.Unlock()
+}
+
+func load() (*Config, error) {
+	var errs Errors
+
+	envFile := os.Getenv("CONFIG_ENV_FILE")
+	dotenv, err := readDotEnv(envFile)
+	if err != nil && (envFile != "" || !errors.Is(err, os.ErrNotExist)) {
+		errs = append(errs, err.Error())
+	}
+
+	src := &source{
+		dotenv:     dotenv,
+		secretsDir: os.Getenv("CONFIG_SECRETS_DIR"),
+	}
+
+	cfg := &Config{}
+	errs = append(errs, src.fill(reflect.ValueOf(cfg).Elem())...)
+	errs = append(errs, cfg.finish()...)
+	errs = append(errs, validate(cfg)...)
+
+	if len(errs) > 0 {
+		return cfg, errs
+	}
+
+	return cfg, nil
+}
+
+// finish fills settings which depend on other settings.
+func (cfg *Config) finish() Errors {
+	var errs Errors
+
+	if cfg.Server.URL == "" {
+		cfg.Server.URL = ":" + strconv.Itoa(cfg.Server.Port)
+	} else if _, port, err := net.SplitHostPort(cfg.Server.URL); err != nil || port == "" {
+		errs = append(errs, fmt.Sprintf("SERVER_URL: %q must be host:port", cfg.Server.URL))
+	}
+
+	if cfg.Media.URLSecret == "" {
+		cfg.Media.URLSecret = "media:" + cfg.JWT.SecretKey
+	}
+
+	// Uploads larger than the body limit are rejected before any size check.
+	limits := []struct {
+		env string
+		mb  int
+	}{
+		{"MEDIA_MAX_IMAGE_SIZE_MB", cfg.Media.MaxImageSizeMB},
+		{"MEDIA_MAX_ATTACHMENT_SIZE_MB", cfg.Media.MaxAttachmentSizeMB},
+		{"MEDIA_MAX_PACKAGE_SIZE_MB", cfg.Media.MaxPackageSizeMB},
+	}
+	for _, l := range limits {
+		if cfg.Server.BodyLimitMB > 0 && l.mb > cfg.Server.BodyLimitMB {
+			errs = append(errs, fmt.Sprintf("%s: %d is larger than SERVER_BODY_LIMIT_MB %d", l.env, l.mb, cfg.Server.BodyLimitMB))
+		}
+	}
+
+	return errs
+}
+
+// validate checks validate tags, errors are named by env var.
+func validate(cfg *Config) Errors {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return f.Tag.Get("env")
+	})
+
+	err := v.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return Errors{err.Error()}
+	}
+
+	errs := make(Errors, 0, len(verrs))
+	for _, e := range verrs {
+		msg := e.Tag()
+		if e.Param() != "" {
+			msg += "=" + e.Param()
+		}
+		errs = append(errs, fmt.Sprintf("%s: must satisfy %s", e.Field(), msg))
+	}
+
+	return errs
+}
+
+// source struct to describe where settings are looked up.
+type source struct {
+	dotenv     map[string]string
+	secretsDir string
+}
+
+func (s *source) lookup(env string) (string, bool, error) {
+	if v, ok := os.LookupEnv(env); ok {
+		return v, true, nil
+	}
+
+	if name, ok := os.LookupEnv(env + "_FILE"); ok {
+		v, err := readSecret(name)
+		return v, err == nil, err
+	}
+
+	if s.secretsDir != "" {
+		v, err := readSecret(filepath.Join(s.secretsDir, env))
+		if err == nil {
+			return v, true, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", false, err
+		}
+	}
+
+	v, ok := s.dotenv[env]
+	return v, ok, nil
+}
+
+// fill sets struct fields from their env tags, nested structs are walked.
+func (s *source) fill(v reflect.Value) Errors {
+	var errs Errors
+
+	for i := 0; i < v.NumField(); i++ {
+		field, f := v.Field(i), v.Type().Field(i)
+
+		env := f.Tag.Get("env")
+		if env == "" {
+			if field.Kind() == reflect.Struct {
+				errs = append(errs, s.fill(field)...)
+			}
+			continue
+		}
+
+		raw, ok, err := s.lookup(env)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", env, err))
+			continue
+		}
+		if !ok || raw == "" {
+			raw = f.Tag.Get("default")
+		}
+		if raw == "" {
+			continue
+		}
+
+		if err := set(field, raw, f.Tag.Get("unit")); err != nil {
+			shown := raw
+			if f.Tag.Get("secret") == "true" {
+				shown = "***"
+			}
+			errs = append(errs, fmt.Sprintf("%s: invalid value %q, %v", env, shown, err))
+		}
+	}
+
+	return errs
+}
+
+// set parses raw into field. Durations are numbers in unit, or Go durations like 1m30s.
+func set(field reflect.Value, raw, unit string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		field.SetInt(int64(n))
+	case bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		field.SetBool(b)
+	case time.Duration:
+		if n, err := strconv.Atoi(raw); err == nil {
+			units := map[string]time.Duration{"s": time.Second, "m": time.Minute, "": time.Second}
+			field.SetInt(int64(time.Duration(n) * units[unit]))
+			return nil
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return errors.New("must be a number or a duration like 30s")
+		}
+		field.SetInt(int64(d))
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+
+	return nil
+}
+
+func readSecret(name string) (string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/audit"
+	"opendavinci/database"
+	"opendavinci/logging"
+	"opendavinci/models"
+	"opendavinci/problem"
+)
+
+// maxAuditEntriesPerPage is the largest limit of GetAuditLog.
+const maxAuditEntriesPerPage = 100
+
+// auditChainBatch is the number of entries VerifyAuditLog reads at a time.
+const auditChainBatch = 1000
+
+// GetAuditLog func gets entries of the audit log by actor, resource and time range, newest first.
+// @Description Get entries of the audit log, newest first.
+// @Summary get audit log
+// @Tags Audit
+// @Produce json
+// @Param actor query string false "Actor, e.g. user:<id>, token:<hash>, anonymous or system"
+// @Param resourceType query string false "Resource type, e.g. course or token"
+// @Param resourceId query string false "Resource ID"
+// @Param from query string false "Entries created at or after, RFC 3339"
+// @Param to query string false "Entries created before, RFC 3339"
+// @Param cursor query string false "Cursor of the page, nextCursor of the previous page"
+// @Param limit query int false "Number of entries, default is 50"
+// @Success 200 {array} models.AuditEntry
+// @Security ApiKeyAuth
+// @Router /v1/admin/audit [get]
+func GetAuditLog(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch filters from query.
+	f := &models.AuditFilter{
+		Actor:        c.Query("actor"),
+		ResourceType: c.Query("resourceType"),
+		ResourceID:   c.Query("resourceId"),
+	}
+	var err error
+	if f.From, err = queryTime(c, "from"); err != nil {
+		return err
+	}
+	if f.To, err = queryTime(c, "to"); err != nil {
+		return err
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		f.BeforeID, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || f.BeforeID < 1 {
+			// Return status 400 and invalid query error.
+			return problem.BadRequest(problem.CodeInvalidQuery, "cursor is malformed")
+		}
+	}
+	f.Limit, err = strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || f.Limit < 1 || f.Limit > maxAuditEntriesPerPage {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, fmt.Sprintf("limit must be an integer from 1 to %d", maxAuditEntriesPerPage))
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get one entry more to know, if there is a next page.
+	limit := f.Limit
+	f.Limit++
+	entries, err := db.GetAuditEntries(f)
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	resp := fiber.Map{
+		"error":   false,
+		"msg":     nil,
+		"count":   len(entries),
+		"entries": entries,
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		resp["count"] = limit
+		resp["entries"] = entries
+		resp["nextCursor"] = strconv.FormatInt(entries[limit-1].ID, 10)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(resp)
+}
+
+// VerifyAuditLog func verifies the hash chain of the audit log from its first chained entry.
+// @Description Verify the hash chain of the audit log.
+// @Summary verify audit log
+// @Tags Audit
+// @Produce json
+// @Success 200 {object} models.AuditVerifyResponse
+// @Security ApiKeyAuth
+// @Router /v1/admin/audit/verify [get]
+func VerifyAuditLog(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	resp := models.AuditVerifyResponse{Valid: true}
+	v := &audit.Verifier{}
+	var after int64
+	for resp.Valid {
+		entries, err := db.GetAuditChain(after, auditChainBatch)
+		if err != nil {
+			// Return status 500 and database error.
+			return problem.From(err)
+		}
+
+		for i := range entries {
+			if err := v.Check(&entries[i]); err != nil {
+				resp.Valid = false
+				resp.BrokenAt = entries[i].ID
+				resp.Reason = err.Error()
+				break
+			}
+		}
+		if len(entries) < auditChainBatch {
+			break
+		}
+		after = entries[len(entries)-1].ID
+	}
+	resp.Checked, resp.Head = v.Checked, v.Head
+
+	// Return status 200 OK.
+	return c.JSON(resp)
+}
+
+// queryTime func for getting the optional RFC 3339 time of query parameter name.
+func queryTime(c *fiber.Ctx, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// Return status 400 and invalid query error.
+		return nil, problem.BadRequest(problem.CodeInvalidQuery, name+" must be a time in RFC 3339 format")
+	}
+
+	return &t, nil
+}
+
+// recordAudit func for recording an event without a row of its own by the actor of the request.
+// Failures are logged, the event happened anyway.
+func recordAudit(c *fiber.Ctx, action, resourceType, resourceID string, details map[string]any) {
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err == nil {
+		err = db.CreateAuditEntry(audit.NewEntry(c.UserContext(), action, resourceType, resourceID, details))
+	}
+	if err != nil {
+		logging.Ctx(c).Warn("Audit entry not recorded", "action", action, "error", err)
+	}
+}
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"archive/zip"
+	"regexp"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/cartridge"
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/logging"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/storage"
+)
+
+// courseKey matches course keys given for packages, they are part of blob keys.
+var courseKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,127}$`)
+
+// ImportPackage func for imports Common Cartridge or SCORM package as a course.
+// @Description Create or update a course with ordered lessons from imsmanifest.xml
+// @Description of the uploaded zip package, multipart field "package".
+// @Description Package elements which were not imported are listed in the response,
+// @Description so are package files which were not stored, importing again stores them.
+// @Summary import Common Cartridge or SCORM package
+// @Tags Admin
+// @Accept mpfd
+// @Produce json
+// @Param package formData file true "Package zip file"
+// @Param courseId query string false "Course ID, default is the manifest identifier"
+// @Param dryRun query bool false "Validate and report without saving"
+// @Success 200 {object} models.ImportResult
+// @Security ApiKeyAuth
+// @Router /v1/admin/import/package [post]
+func ImportPackage(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
+	}
+
+	// Checking, if now time greater than expiration from JWT.
+	if now > claims.Expires {
+		// Return status 401 and unauthorized error message.
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	file, err := c.FormFile("package")
+	if err != nil {
+		return problem.Wrap(err, fiber.StatusBadRequest, problem.CodeBadRequest, "multipart field package is required")
+	}
+
+	// Check size before reading the file.
+	if limit := mediaLimit(config.Get().Media.MaxPackageSizeMB); file.Size > limit {
+		return problem.New(fiber.StatusRequestEntityTooLarge, problem.CodeTooLarge, "package is too large")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return problem.Wrap(err, fiber.StatusBadRequest, problem.CodeBadRequest, "package cannot be read")
+	}
+	defer f.Close()
+
+	zr, err := zip.NewReader(f, file.Size)
+	if err != nil {
+		return problem.Wrap(err, fiber.StatusBadRequest, problem.CodePackageInvalid, err.Error())
+	}
+
+	// Course key names the folder of the package files in the blob store.
+	courseID := c.Query("courseId")
+	if courseID != "" && !courseKey.MatchString(courseID) {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, "courseId must be 1 to 128 letters, digits, - or _")
+	}
+
+	// Convert manifest to course with lessons.
+	pkg, err := cartridge.Convert(zr, courseID)
+	if err != nil {
+		return problem.Wrap(err, fiber.StatusUnprocessableEntity, problem.CodePackageInvalid, err.Error())
+	}
+
+	if err := validateCatalogCourse(pkg.Course); err != nil {
+		return problem.Wrap(err, fiber.StatusUnprocessableEntity, problem.CodePackageInvalid, err.Error()).
+			With("unsupported", pkg.Unsupported)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	dryRun := c.QueryBool("dryRun")
+
+	result, err := db.ImportCourse(pkg.Course, dryRun)
+	if err != nil {
+		// Return status 500 and error message.
+		return problem.From(err)
+	}
+	result.Row = file.Filename
+
+	// Store attachments of the imported lessons once the import is committed.
+	// Keys depend on course and path only, importing again stores missing files.
+	var missing []string
+	if !dryRun {
+		countImports([]*models.ImportResult{result})
+		if missing, err = storePackageFiles(c, pkg.Files); err != nil {
+			return problem.From(err)
+		}
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":        false,
+		"msg":          nil,
+		"dryRun":       dryRun,
+		"result":       result,
+		"files":        len(pkg.Files) - len(missing),
+		"missingFiles": missing,
+		"unsupported":  pkg.Unsupported,
+	})
+}
+
+// storePackageFiles func for copying package files to the blob store.
+// The course is imported already, so files which fail are logged and returned as missing.
+func storePackageFiles(c *fiber.Ctx, files []cartridge.PackageFile) (missing []string, err error) {
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pf := range files {
+		if err := storePackageFile(c, store, pf); err != nil {
+			logging.Ctx(c).Warn("Package file not stored", "key", pf.Key, "error", err)
+			missing = append(missing, pf.Key)
+		}
+	}
+
+	return missing, nil
+}
+
+func storePackageFile(c *fiber.Ctx, store storage.BlobStore, pf cartridge.PackageFile) error {
+	r, err := pf.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return store.Put(c.UserContext(), pf.Key, r, pf.ContentType)
+}
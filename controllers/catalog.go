@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"opendavinci/database"
+	"opendavinci/logging"
+	"opendavinci/metrics"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/render"
+)
+
+// maxCatalogRow limits the size of one imported course with its lessons.
+const maxCatalogRow = 16 << 20
+
+// catalogRow struct to describe one course document of an import.
+type catalogRow struct {
+	name string
+	data []byte
+}
+
+// ImportCatalog func for imports courses with their lessons from NDJSON or zip.
+// @Description Upsert courses and lessons by courseId and lessonId.
+// @Description Body is NDJSON with one course per line, or a zip archive of .json and .ndjson files.
+// @Summary import course catalog
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param dryRun query bool false "Validate and report without saving"
+// @Success 200 {array} models.ImportResult
+// @Security ApiKeyAuth
+// @Router /v1/admin/import [post]
+func ImportCatalog(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
+	}
+
+	// Checking, if now time greater than expiration from JWT.
+	if now > claims.Expires {
+		// Return status 401 and unauthorized error message.
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	// Split body into course documents.
+	var rows []catalogRow
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), "application/zip") {
+		rows, err = zipRows(c.Body())
+	} else {
+		rows, err = ndjsonRows(bytes.NewReader(c.Body()), "line ")
+	}
+	if err != nil {
+		// Return status 400 and parse error.
+		return problem.Wrap(err, fiber.StatusBadRequest, problem.CodeCatalogInvalid, err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	dryRun := c.QueryBool("dryRun")
+	results := make([]*models.ImportResult, 0, len(rows))
+	failed := 0
+
+	// Every row is imported on its own, one bad row does not stop the others.
+	for _, row := range rows {
+		course, err := decodeCatalogRow(row.data)
+		if err != nil {
+			results = append(results, &models.ImportResult{Row: row.name, Error: err.Error()})
+			failed++
+			continue
+		}
+
+		result, err := db.ImportCourse(course, dryRun)
+		if err != nil {
+			result = &models.ImportResult{CourseID: course.CourseID, Error: err.Error()}
+			failed++
+		}
+		result.Row = row.name
+		results = append(results, result)
+	}
+
+	if !dryRun {
+		countImports(results)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"msg":     nil,
+		"dryRun":  dryRun,
+		"count":   len(results),
+		"failed":  failed,
+		"results": results,
+	})
+}
+
+// ExportCatalog func for exports courses with their lessons as NDJSON or zip.
+// @Description Stream all courses with their lessons, one course per NDJSON line
+// @Description or one courses/{courseId}.json file per course in a zip archive.
+// @Summary export course catalog
+// @Tags Admin
+// @Produce json
+// @Param format query string false "Export format" Enums(ndjson, zip)
+// @Success 200 {array} models.CatalogCourse
+// @Security ApiKeyAuth
+// @Router /v1/admin/export [get]
+func ExportCatalog(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
+	}
+
+	// Checking, if now time greater than expiration from JWT.
+	if now > claims.Expires {
+		// Return status 401 and unauthorized error message.
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	format := c.Query("format", "ndjson")
+	if format != "ndjson" && format != "zip" {
+		return problem.BadRequest(problem.CodeInvalidQuery, "format must be ndjson or zip")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	if format == "zip" {
+		c.Set(fiber.HeaderContentType, "application/zip")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="catalog.zip"`)
+	} else {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	}
+
+	// Status is sent before the first course, so errors can only be logged.
+	// Context is reused once the handler returned, keep what the stream needs.
+	logger, ctx := logging.Ctx(c), c.UserContext()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var err error
+		if format == "zip" {
+			zw := zip.NewWriter(w)
+			err = db.ExportCourses(func(course *models.CatalogCourse) error {
+				f, err := zw.Create("courses/" + url.PathEscape(course.CourseID) + ".json")
+				if err != nil {
+					return err
+				}
+				return json.NewEncoder(f).Encode(course)
+			})
+			if err == nil {
+				err = zw.Close()
+			}
+		} else {
+			enc := json.NewEncoder(w)
+			err = db.ExportCourses(func(course *models.CatalogCourse) error {
+				if err := enc.Encode(course); err != nil {
+					return err
+				}
+				return w.Flush()
+			})
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "Catalog export failure", "error", err)
+		}
+	})
+
+	return nil
+}
+
+// countImports func for counting imported courses by action.
+func countImports(results []*models.ImportResult) {
+	for _, r := range results {
+		action := r.Action
+		if r.Error != "" {
+			action = "failed"
+		}
+		metrics.CatalogImports.WithLabelValues(action).Inc()
+	}
+}
+
+// decodeCatalogRow func for decoding and validating one course document.
+func decodeCatalogRow(data []byte) (*models.CatalogCourse, error) {
+	course := &models.CatalogCourse{}
+	if err := json.Unmarshal(data, course); err != nil {
+		return nil, err
+	}
+
+	if err := validateCatalogCourse(course); err != nil {
+		return nil, err
+	}
+
+	return course, nil
+}
+
+// validateCatalogCourse func for validating course with its lessons before import.
+func validateCatalogCourse(course *models.CatalogCourse) error {
+	// Create a new validator for a Course model.
+	validate := NewValidator()
+
+	// IDs are assigned by database, rows are matched by courseId and lessonId.
+	course.ID = uuid.New()
+	if err := validate.Struct(&course.Course); err != nil {
+		return validationError(err)
+	}
+
+	for i := range course.Lessons {
+		lesson := &course.Lessons[i]
+		lesson.ID = uuid.New()
+		if lesson.Position == 0 {
+			lesson.Position = i + 1
+		}
+		if lesson.Format == "" {
+			lesson.Format = render.FormatText
+		}
+		if err := validate.Struct(lesson); err != nil {
+			return errors.New("lesson " + strconv.Itoa(i+1) + ": " + validationError(err).Error())
+		}
+	}
+
+	return nil
+}
+
+// validationError func for joining validation errors into one error.
+func validationError(err error) error {
+	fields := ValidatorErrors(err)
+
+	msgs := make([]string, 0, len(fields))
+	for _, msg := range fields {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// ndjsonRows func for splitting NDJSON into rows, skipping blank lines.
+func ndjsonRows(r io.Reader, prefix string) ([]catalogRow, error) {
+	rows := []catalogRow{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxCatalogRow)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		rows = append(rows, catalogRow{
+			name: prefix + strconv.Itoa(line),
+			data: append([]byte(nil), scanner.Bytes()...),
+		})
+	}
+
+	return rows, scanner.Err()
+}
+
+// zipRows func for reading rows from .json and .ndjson files of zip archive.
+func zipRows(body []byte) ([]catalogRow, error) {
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []catalogRow{}
+	for _, f := range zr.File {
+		ext := path.Ext(f.Name)
+		if f.FileInfo().IsDir() || (ext != ".json" && ext != ".ndjson") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		// Limit decompressed size to protect from zip bombs.
+		data, err := io.ReadAll(io.LimitReader(rc, maxCatalogRow+1))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxCatalogRow {
+			return nil, errors.New(f.Name + " is too large")
+		}
+
+		if ext == ".json" {
+			rows = append(rows, catalogRow{name: f.Name, data: data})
+			continue
+		}
+
+		fileRows, err := ndjsonRows(bytes.NewReader(data), f.Name+":")
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, fileRows...)
+	}
+
+	return rows, nil
+}
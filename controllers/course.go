@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,48 +10,83 @@ import (
 	"opendavinci/models"
 
 	"opendavinci/database"
+	"opendavinci/metrics"
+	"opendavinci/problem"
+	"opendavinci/queries"
 )
 
 // GetCourses func gets all exists courses.
+// With limit, courses are sent in pages ordered by creation,
+// nextCursor of a page is the cursor of the next one.
 // @Description Get all exists courses.
 // @Summary get all exists courses
 // @Tags Courses
 // @Accept json
 // @Produce json
+// @Param limit query int false "Courses per page, default is all"
+// @Param cursor query string false "Cursor of the page"
 // @Success 200 {array} models.Course
 // @Router /v1/courses [get]
 func GetCourses(c *fiber.Ctx) error {
+	// Catch page from URL.
+	limit, err := strconv.Atoi(c.Query("limit", "0"))
+	if err != nil || limit < 0 || limit > maxCoursesPerPage {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, fmt.Sprintf("limit must be an integer from 0 to %d", maxCoursesPerPage))
+	}
+	after, err := queries.ParseCourseCursor(c.Query("cursor"))
+	if err != nil {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, "cursor is malformed")
+	}
+
 	// Create database connection.
-	db, err := database.OpenDBConnection()
+	db, err := database.OpenDBConnection(c.UserContext())
 	if err != nil {
-		// Return status 500 and database connection error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 503 and database connection error.
+		return problem.From(err)
 	}
 
-	// Get all courses.
-	courses, err := db.GetCourses()
+	// Get all courses, or one more than the page to know if there is a next one.
+	var courses []models.Course
+	if limit == 0 {
+		courses, err = db.GetCourses()
+	} else {
+		courses, err = db.GetCoursesAfter(after, limit+1)
+	}
 	if err != nil {
-		// Return, if courses not found.
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":   true,
-			"msg":     "courses were not found",
-			"count":   0,
-			"courses": nil,
-		})
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	next := ""
+	if limit > 0 && len(courses) > limit {
+		courses = courses[:limit]
+		next = queries.CourseCursorOf(courses[limit-1]).String()
+	}
+
+	// Set signed image URLs.
+	for i := range courses {
+		SignCourseImage(c.UserContext(), &courses[i])
 	}
 
 	// Return status 200 OK.
-	return c.JSON(fiber.Map{
+	resp := fiber.Map{
 		"error":   false,
 		"msg":     nil,
 		"count":   len(courses),
 		"courses": courses,
-	})
+	}
+	if next != "" {
+		resp["nextCursor"] = next
+	}
+
+	return c.JSON(resp)
 }
 
+// maxCoursesPerPage is the largest limit of GetCourses.
+const maxCoursesPerPage = 100
+
 // GetCourse func gets course by given ID or 404 error.
 // @Description Get course by given ID.
 // @Summary get course by given ID
@@ -63,33 +100,27 @@ func GetCourse(c *fiber.Ctx) error {
 	// Catch course ID from URL.
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
 	}
 
 	// Create database connection.
-	db, err := database.OpenDBConnection()
+	db, err := database.OpenDBConnection(c.UserContext())
 	if err != nil {
-		// Return status 500 and database connection error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 503 and database connection error.
+		return problem.From(err)
 	}
 
 	// Get course by ID.
 	course, err := db.GetCourse(id)
 	if err != nil {
 		// Return, if course not found.
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error":  true,
-			"msg":    "course with the given ID is not found",
-			"course": nil,
-		})
+		return problem.Lookup(err, problem.CodeCourseNotFound, "course with the given ID is not found")
 	}
 
+	// Set signed image URL.
+	SignCourseImage(c.UserContext(), &course)
+
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
 		"error":  false,
@@ -115,11 +146,8 @@ func CreateCourse(c *fiber.Ctx) error {
 	// Get claims from JWT.
 	claims, err := ExtractTokenMetadata(c)
 	if err != nil {
-		// Return status 500 and JWT parse error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
 	}
 
 	// Set expiration time from JWT data of current course.
@@ -128,10 +156,7 @@ func CreateCourse(c *fiber.Ctx) error {
 	// Checking, if now time greater than expiration from JWT.
 	if now > expires {
 		// Return status 401 and unauthorized error message.
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": true,
-			"msg":   "unauthorized, check expiration time of your token",
-		})
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
 	}
 
 	// Create new Course struct
@@ -139,21 +164,15 @@ func CreateCourse(c *fiber.Ctx) error {
 
 	// Check, if received JSON data is valid.
 	if err := c.BodyParser(course); err != nil {
-		// Return status 400 and error message.
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 400 and parse error.
+		return problem.InvalidBody(err)
 	}
 
 	// Create database connection.
-	db, err := database.OpenDBConnection()
+	db, err := database.OpenDBConnection(c.UserContext())
 	if err != nil {
-		// Return status 500 and database connection error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 503 and database connection error.
+		return problem.From(err)
 	}
 
 	// Create a new validator for a Course model.
@@ -167,19 +186,14 @@ func CreateCourse(c *fiber.Ctx) error {
 	// Validate course fields.
 	if err := validate.Struct(course); err != nil {
 		// Return, if some fields are not valid.
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   ValidatorErrors(err),
-		})
+		return problem.Validation(err)
 	}
 
 	if err := db.CreateCourse(course); err != nil {
 		// Return status 500 and error message.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		return problem.From(err)
 	}
+	metrics.CoursesCreated.Inc()
 
 	// Return status 200 OK.
 	return c.JSON(fiber.Map{
@@ -207,11 +221,8 @@ func UpdateCourse(c *fiber.Ctx) error {
 	// Get claims from JWT.
 	claims, err := ExtractTokenMetadata(c)
 	if err != nil {
-		// Return status 500 and JWT parse error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
 	}
 
 	// Set expiration time from JWT data of current course.
@@ -220,10 +231,7 @@ func UpdateCourse(c *fiber.Ctx) error {
 	// Checking, if now time greater than expiration from JWT.
 	if now > expires {
 		// Return status 401 and unauthorized error message.
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": true,
-			"msg":   "unauthorized, check expiration time of your token",
-		})
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
 	}
 
 	// Create new Course struct
@@ -231,31 +239,22 @@ func UpdateCourse(c *fiber.Ctx) error {
 
 	// Check, if received JSON data is valid.
 	if err := c.BodyParser(course); err != nil {
-		// Return status 400 and error message.
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 400 and parse error.
+		return problem.InvalidBody(err)
 	}
 
 	// Create database connection.
-	db, err := database.OpenDBConnection()
+	db, err := database.OpenDBConnection(c.UserContext())
 	if err != nil {
-		// Return status 500 and database connection error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 503 and database connection error.
+		return problem.From(err)
 	}
 
 	// Checking, if course with given ID does exist.
 	foundedCourse, err := db.GetCourse(course.ID)
 	if err != nil {
 		// Return status 404 and course not found error.
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": true,
-			"msg":   "course with this ID not found",
-		})
+		return problem.Lookup(err, problem.CodeCourseNotFound, "course with this ID not found")
 	}
 
 	// Set initialized default data for course:
@@ -267,20 +266,15 @@ func UpdateCourse(c *fiber.Ctx) error {
 	// Validate course fields.
 	if err := validate.Struct(course); err != nil {
 		// Return, if some fields are not valid.
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   ValidatorErrors(err),
-		})
+		return problem.Validation(err)
 	}
 
 	// Update course by given ID.
 	if err := db.UpdateCourse(foundedCourse.ID, course); err != nil {
 		// Return status 500 and error message.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		return problem.From(err)
 	}
+	metrics.CoursesUpdated.Inc()
 
 	// Return status 201.
 	return c.SendStatus(fiber.StatusCreated)
@@ -303,11 +297,8 @@ func DeleteCourse(c *fiber.Ctx) error {
 	// Get claims from JWT.
 	claims, err := ExtractTokenMetadata(c)
 	if err != nil {
-		// Return status 500 and JWT parse error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
 	}
 
 	// Set expiration time from JWT data of current course.
@@ -316,10 +307,7 @@ func DeleteCourse(c *fiber.Ctx) error {
 	// Checking, if now time greater than expiration from JWT.
 	if now > expires {
 		// Return status 401 and unauthorized error message.
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": true,
-			"msg":   "unauthorized, check expiration time of your token",
-		})
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
 	}
 
 	// Create new Course struct
@@ -327,11 +315,8 @@ func DeleteCourse(c *fiber.Ctx) error {
 
 	// Check, if received JSON data is valid.
 	if err := c.BodyParser(course); err != nil {
-		// Return status 400 and error message.
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 400 and parse error.
+		return problem.InvalidBody(err)
 	}
 
 	// Create a new validator for a Course model.
@@ -340,39 +325,27 @@ func DeleteCourse(c *fiber.Ctx) error {
 	// Validate only one course field ID.
 	if err := validate.StructPartial(course, "id"); err != nil {
 		// Return, if some fields are not valid.
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   ValidatorErrors(err),
-		})
+		return problem.Validation(err)
 	}
 
 	// Create database connection.
-	db, err := database.OpenDBConnection()
+	db, err := database.OpenDBConnection(c.UserContext())
 	if err != nil {
-		// Return status 500 and database connection error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		// Return status 503 and database connection error.
+		return problem.From(err)
 	}
 
 	// Checking, if course with given ID does exist.
 	foundedCourse, err := db.GetCourse(course.ID)
 	if err != nil {
 		// Return status 404 and course not found error.
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": true,
-			"msg":   "course with this ID not found",
-		})
+		return problem.Lookup(err, problem.CodeCourseNotFound, "course with this ID not found")
 	}
 
 	// Delete course by given ID.
 	if err := db.DeleteCourse(foundedCourse.ID); err != nil {
 		// Return status 500 and error message.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		return problem.From(err)
 	}
 
 	// Return status 204 no content.
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/events"
+	"opendavinci/logging"
+	"opendavinci/models"
+	"opendavinci/problem"
+)
+
+// missedPerRead is the number of missed events read at once.
+const missedPerRead = 100
+
+// reconnectAfter is the delay of EventSource clients before they reconnect to a closed stream.
+const reconnectAfter = 3 * time.Second
+
+// GetEvents func streams changes of courses and lessons as Server-Sent Events.
+// Each event is sent with its ID, so a client which reconnects with Last-Event-ID
+// gets the events it missed first, for as long as EVENTS_RETENTION keeps them.
+// @Description Stream changes of courses and lessons.
+// @Summary stream changes
+// @Tags Events
+// @Produce text/event-stream
+// @Param types query string false "Resource types, comma separated: course, lesson"
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Success 200 {object} models.Event
+// @Router /v1/events [get]
+func GetEvents(c *fiber.Ctx) error {
+	// Catch resource types from URL, default is all.
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !slices.Contains(events.Types, t) {
+				// Return status 400 and invalid query error.
+				return problem.BadRequest(problem.CodeInvalidQuery, "types must be a list of "+strings.Join(events.Types, ", "))
+			}
+			types = append(types, t)
+		}
+	}
+
+	// Catch ID of the last event, sent by EventSource on reconnect, or as query on the first connect.
+	lastID := c.Get("Last-Event-ID", c.Query("lastEventId"))
+	var after int64
+	if lastID != "" {
+		id, err := strconv.ParseInt(lastID, 10, 64)
+		if err != nil || id < 0 {
+			// Return status 400 and invalid header error.
+			return problem.BadRequest(problem.CodeBadRequest, "Last-Event-ID must be an event ID")
+		}
+		after = id
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Subscribe before missed events are read, so none is lost in between.
+	sub, err := events.Subscribe(types)
+	if errors.Is(err, events.ErrTooManySubscribers) || errors.Is(err, events.ErrStopped) {
+		// Return status 503, clients retry.
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(reconnectAfter.Seconds())))
+		return problem.New(fiber.StatusServiceUnavailable, problem.CodeUnavailable, err.Error())
+	}
+	if err != nil {
+		return problem.From(err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set("X-Accel-Buffering", "no") // proxies must not buffer the stream
+
+	// Status is sent before the first event, so errors can only be logged.
+	// Context is reused once the handler returned, keep what the stream needs.
+	logger, ctx := logging.Ctx(c), c.UserContext()
+	heartbeat := config.Get().Events.Heartbeat
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		fmt.Fprintf(w, "retry: %d\n\n", reconnectAfter.Milliseconds())
+
+		// Send missed events, live ones up to the last of them in order of the feed were sent already.
+		var replayed models.Event
+		if lastID != "" {
+			for {
+				missed, err := db.GetEventsAfter(after, missedPerRead)
+				if err != nil {
+					logger.ErrorContext(ctx, "Missed events not sent", "error", err)
+					return
+				}
+				for _, e := range missed {
+					after, replayed = e.ID, e
+					if len(types) > 0 && !slices.Contains(types, e.Type) {
+						continue
+					}
+					if err := writeEvent(w, e); err != nil {
+						return
+					}
+				}
+				if len(missed) < missedPerRead {
+					break
+				}
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		// Comments keep idle streams open through proxies.
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case e, ok := <-sub.Events():
+				if !ok {
+					// Stream lagged behind or server shuts down, the client resumes with Last-Event-ID.
+					return
+				}
+				if !e.After(replayed) {
+					continue
+				}
+				if err := writeEvent(w, e); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if _, err := w.WriteString(": ping\n\n"); err != nil {
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				// Client is gone.
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent func for writing e as message of the stream, named type.action, e.g. course.updated.
+func writeEvent(w *bufio.Writer, e models.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s.%s\ndata: %s\n\n", e.ID, e.Type, e.Action, data)
+
+	return err
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/config"
+	"opendavinci/graph"
+	"opendavinci/problem"
+)
+
+// GraphQL func for running a GraphQL query over courses, lessons, users and enrollments.
+// The access token is optional, private fields are resolved only with a valid one.
+// @Description Run a GraphQL query.
+// @Summary run a GraphQL query
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Success 200 {object} graphql.Result
+// @Router /graphql [post]
+func GraphQL(c *fiber.Ctx) error {
+	// Create a new request struct.
+	req := &graph.Request{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(req); err != nil {
+		// Return status 400 and error message.
+		return problem.InvalidBody(err)
+	}
+	if req.Query == "" {
+		return problem.BadRequest(problem.CodeInvalidBody, "query is required")
+	}
+
+	// Get the viewer from the access token, a rejected token is an error like in private routes.
+	viewer := graph.Viewer{}
+	if extractToken(c) != "" {
+		claims, err := ExtractTokenMetadata(c)
+		if err != nil {
+			// Return status 401 and JWT parse error.
+			return problem.From(err)
+		}
+		viewer = graph.Viewer{Authenticated: true, Subject: claims.Subject, Role: claims.Role}
+	}
+
+	cfg := config.Get().GraphQL
+	res, valid := graph.Execute(c.UserContext(), *req, viewer, graph.Limits{
+		MaxDepth:      cfg.MaxDepth,
+		MaxComplexity: cfg.MaxComplexity,
+	})
+	if !valid {
+		// Return status 400, the query did not run.
+		c.Status(fiber.StatusBadRequest)
+	}
+
+	// Return status 200 OK, errors of fields are sent with the data.
+	return c.JSON(res)
+}
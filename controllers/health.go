@@ -0,0 +1,125 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/database"
+	"opendavinci/health"
+	"opendavinci/logging"
+	"opendavinci/storage"
+)
+
+// healthTimeout limits each readiness check, probes time out after 1 second by default.
+const healthTimeout = 800 * time.Millisecond
+
+// started is set once the startup probe succeeded.
+var started atomic.Bool
+
+func init() {
+	health.Register("database", database.Ping)
+	health.Register("migrations", checkMigrations)
+	health.Register("blobStore", checkBlobStore)
+}
+
+// Healthz func for liveness probe, the process is serving requests.
+// @Description Liveness probe, always 200 while the server is running.
+// @Summary liveness probe
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Router /healthz [get]
+func Healthz(c *fiber.Ctx) error {
+	return c.JSON(health.Report{Status: health.StatusOK, Checks: map[string]health.Result{}})
+}
+
+// Readyz func for readiness probe, runs all registered checks.
+// @Description Readiness probe with the result of every check: database, migrations and subsystems.
+// @Summary readiness probe
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func Readyz(c *fiber.Ctx) error {
+	return sendReport(c, health.Run(c.UserContext(), healthTimeout))
+}
+
+// Startupz func for startup probe, ready once database migrations are applied.
+// @Description Startup probe, 503 until the database is reachable and migrated to the version of this build.
+// @Summary startup probe
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /startupz [get]
+func Startupz(c *fiber.Ctx) error {
+	// Started once, the readiness probe takes over.
+	if started.Load() {
+		return Healthz(c)
+	}
+
+	report := health.Run(c.UserContext(), healthTimeout, "database", "migrations")
+	if report.Status == health.StatusOK {
+		started.Store(true)
+	}
+
+	return sendReport(c, report)
+}
+
+func sendReport(c *fiber.Ctx, report *health.Report) error {
+	// Probes must never be cached.
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	if report.Status != health.StatusOK {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+
+	// Errors may tell hosts or versions, they are logged but not sent.
+	for _, name := range slices.Sorted(maps.Keys(report.Checks)) {
+		if result := report.Checks[name]; result.Status != health.StatusOK {
+			logging.Ctx(c).Warn("Health check failed", "check", name, "reason", result.Reason, "error", result.Error)
+		}
+	}
+
+	return c.JSON(report)
+}
+
+// checkMigrations func for checking database schema is at least the version of this build.
+// Newer versions are fine, they are applied before a new revision takes traffic.
+func checkMigrations(ctx context.Context) error {
+	version, dirty, err := database.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return health.Fail("migration_dirty", fmt.Errorf("migration %d failed, database is dirty", version))
+	}
+	if want := database.LatestMigration(); version < want {
+		return health.Fail("migration_behind", fmt.Errorf("migration version is %d, want %d", version, want))
+	}
+
+	return nil
+}
+
+// checkBlobStore func for checking blob store can be reached.
+func checkBlobStore(ctx context.Context) error {
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		return err
+	}
+
+	// Missing object means the store answered.
+	if _, err := store.Stat(ctx, ".healthz"); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
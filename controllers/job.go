@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/audit"
+	"opendavinci/database"
+	"opendavinci/jobs"
+	"opendavinci/models"
+	"opendavinci/problem"
+)
+
+// maxJobsPerPage is the largest limit of GetJobs.
+const maxJobsPerPage = 100
+
+// jobStatuses are the statuses GetJobs filters by.
+var jobStatuses = []string{models.JobPending, models.JobRunning, models.JobDone, models.JobDead}
+
+// GetJobs func gets background jobs by status, dead ones by default, newest first.
+// @Description Get background jobs by status and kind, newest first.
+// @Summary get jobs
+// @Tags Jobs
+// @Produce json
+// @Param status query string false "Status: pending, running, done or dead, default is dead"
+// @Param kind query string false "Kind, e.g. webhook.deliver, default is all"
+// @Param limit query int false "Number of jobs, default is 50"
+// @Success 200 {array} models.Job
+// @Security ApiKeyAuth
+// @Router /v1/admin/jobs [get]
+func GetJobs(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch filters from query.
+	status := c.Query("status", models.JobDead)
+	if !slices.Contains(jobStatuses, status) {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, "status must be one of pending, running, done or dead")
+	}
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit < 1 || limit > maxJobsPerPage {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, fmt.Sprintf("limit must be an integer from 1 to %d", maxJobsPerPage))
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	list, err := db.GetJobs(status, c.Query("kind"), limit)
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"count": len(list),
+		"jobs":  list,
+	})
+}
+
+// GetJob func gets background job by given ID.
+// @Description Get background job by given ID.
+// @Summary get job by given ID
+// @Tags Jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} models.Job
+// @Security ApiKeyAuth
+// @Router /v1/admin/jobs/{id} [get]
+func GetJob(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch job ID from URL.
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get job by ID.
+	job, err := db.GetJob(id)
+	if err != nil {
+		// Return, if job not found.
+		return problem.Lookup(err, problem.CodeJobNotFound, "job with the given ID is not found")
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"job":   job,
+	})
+}
+
+// RetryJob func for queues dead job by given ID again, with all its attempts.
+// @Description Queue a dead job again.
+// @Summary retry dead job
+// @Tags Jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 202 {object} models.Job
+// @Security ApiKeyAuth
+// @Router /v1/admin/jobs/{id}/retry [post]
+func RetryJob(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch job ID from URL.
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// The job is queued again together with its audit entry.
+	err = db.InTx(func(tx *database.Queries) error {
+		if err := tx.RequeueJob(id); err != nil {
+			return err
+		}
+		return tx.CreateAuditEntry(audit.NewEntry(c.UserContext(), "job.retried", "job", strconv.FormatInt(id, 10), nil))
+	})
+	if err != nil {
+		// Return, if dead job not found.
+		return problem.Lookup(err, problem.CodeJobNotFound, "dead job with the given ID is not found")
+	}
+	jobs.Wake()
+
+	job, err := db.GetJob(id)
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	// Return status 202 accepted, the job is run by a worker.
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"job":   job,
+	})
+}
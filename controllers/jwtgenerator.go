@@ -1,26 +1,44 @@
 package controllers
 
 import (
-	"os"
-	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+
+	"opendavinci/config"
+	"opendavinci/models"
 )
 
 // GenerateNewAccessToken func for generate a new Access token.
+// It has no subject, its client is known by the token only.
 func GenerateNewAccessToken() (string, error) {
-	// Set secret key from .env file.
-	secret := os.Getenv("JWT_SECRET_KEY")
-
-	// Set expires minutes count for secret key from .env file.
-	minutesCount, _ := strconv.Atoi(os.Getenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT"))
+	// Create a new claims.
+	return signAccessToken(jwt.MapClaims{})
+}
 
+// GenerateUserAccessToken func for generate a new Access token of user,
+// with the ID of the user as subject and its role.
+func GenerateUserAccessToken(user models.User) (string, error) {
 	// Create a new claims.
 	claims := jwt.MapClaims{}
 
+	// Set claims of the user:
+	claims["sub"] = user.ID.String()
+	if user.Role != "" {
+		claims["role"] = user.Role
+	}
+
+	return signAccessToken(claims)
+}
+
+// signAccessToken func for setting expiration of claims and signing them.
+func signAccessToken(claims jwt.MapClaims) (string, error) {
+	// Set secret key and expiration from configuration.
+	cfg := config.Get().JWT
+	secret := cfg.SecretKey
+
 	// Set public claims:
-	claims["exp"] = time.Now().Add(time.Minute * time.Duration(minutesCount)).Unix()
+	claims["exp"] = time.Now().Add(cfg.ExpireMinutes).Unix()
 
 	// Create a new JWT access token with claims.
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
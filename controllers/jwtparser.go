@@ -1,42 +1,83 @@
 package controllers
 
 import (
-	"os"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt"
+
+	"opendavinci/config"
+	"opendavinci/problem"
 )
 
 // TokenMetadata struct to describe metadata in JWT.
 type TokenMetadata struct {
 	Expires int64
+	Subject string // sub claim, empty for tokens of GetNewAccessToken
+	Role    string // role claim, e.g. admin
 }
 
 // ExtractTokenMetadata func to extract metadata from JWT.
 func ExtractTokenMetadata(c *fiber.Ctx) (*TokenMetadata, error) {
-	token, err := verifyToken(c)
+	return ParseTokenMetadata(extractToken(c))
+}
+
+// ParseTokenMetadata func to verify the token string and extract its metadata,
+// e.g. of an access token sent in gRPC metadata.
+func ParseTokenMetadata(tokenString string) (*TokenMetadata, error) {
+	token, err := verifyToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
 	// Setting and checking token and credentials.
 	claims, ok := token.Claims.(jwt.MapClaims)
-	if ok && token.Valid {
-		// Expires time.
-		expires := int64(claims["exp"].(float64))
+	if !ok || !token.Valid {
+		return nil, problem.Unauthorized(problem.CodeTokenInvalid, "access token claims are not valid")
+	}
+
+	// Expires time, a token without it must not panic the handler.
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, problem.Unauthorized(problem.CodeTokenInvalid, "access token has no expiration time")
+	}
+
+	// Optional claims of the user.
+	sub, _ := claims["sub"].(string)
+	role, _ := claims["role"].(string)
 
-		return &TokenMetadata{
-			Expires: expires,
-		}, nil
+	return &TokenMetadata{
+		Expires: int64(exp),
+		Subject: sub,
+		Role:    role,
+	}, nil
+}
+
+// authorize func for checking the access token of a private route, it must be valid and not expired.
+func authorize(c *fiber.Ctx) (*TokenMetadata, error) {
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 401 and JWT parse error.
+		return nil, problem.From(err)
 	}
 
-	return nil, err
+	// Checking, if now time greater than expiration from JWT.
+	if time.Now().Unix() > claims.Expires {
+		// Return status 401 and unauthorized error message.
+		return nil, problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	return claims, nil
 }
 
 func extractToken(c *fiber.Ctx) string {
-	bearToken := c.Get("Authorization")
+	return BearerToken(c.Get("Authorization"))
+}
 
+// BearerToken func to get the token of an Authorization header, "Bearer <token>".
+func BearerToken(bearToken string) string {
 	// Normally Authorization HTTP header.
 	onlyToken := strings.Split(bearToken, " ")
 	if len(onlyToken) == 2 {
@@ -46,8 +87,10 @@ func extractToken(c *fiber.Ctx) string {
 	return ""
 }
 
-func verifyToken(c *fiber.Ctx) (*jwt.Token, error) {
-	tokenString := extractToken(c)
+func verifyToken(tokenString string) (*jwt.Token, error) {
+	if tokenString == "" {
+		return nil, problem.Unauthorized(problem.CodeTokenMissing, "missing or malformed access token")
+	}
 
 	token, err := jwt.Parse(tokenString, jwtKeyFunc)
 	if err != nil {
@@ -58,5 +101,5 @@ func verifyToken(c *fiber.Ctx) (*jwt.Token, error) {
 }
 
 func jwtKeyFunc(token *jwt.Token) (interface{}, error) {
-	return []byte(os.Getenv("JWT_SECRET_KEY")), nil
+	return []byte(config.Get().JWT.SecretKey), nil
 }
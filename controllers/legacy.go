@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"opendavinci/models"
+
+	"opendavinci/database"
+	"opendavinci/problem"
+)
+
+// LegacyGetCourses func gets all courses for the pre-v1 API.
+// Deprecated: use GetCourses at /api/v1/courses.
+func LegacyGetCourses(c *fiber.Ctx) error {
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	courses := []models.Course{}
+	query := `SELECT * FROM courses_v`
+	err = db.CourseQueries.Select(&courses, query)
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	return c.JSON(courses)
+}
+
+// LegacyCreateCourse func creates a course from raw JSON for the pre-v1 API.
+// Deprecated: use CreateCourse at /api/v1/course.
+func LegacyCreateCourse(c *fiber.Ctx) error {
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+	// todo sanitize
+	js := c.Body()
+
+	stmt := `INSERT INTO courses (rawdata) VALUES ($1) RETURNING *`
+	ds, err := db.CourseQueries.Exec(stmt, string(js))
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	return c.JSON(ds)
+}
+
+// LegacyGetLessons func shows request headers to help debug cf app token.
+// Deprecated: use GetLesson at /api/v1/lesson/{id}.
+func LegacyGetLessons(c *fiber.Ctx) error {
+	// show headers to help debug cf app token
+	dump := c.GetReqHeaders()
+	return c.Status(fiber.StatusOK).JSON(dump)
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"opendavinci/database"
+	"opendavinci/problem"
+	"opendavinci/render"
+)
+
+// GetLesson func gets lesson by given ID or 404 error.
+// @Description Get lesson by given ID.
+// @Summary get lesson by given ID
+// @Tags Lesson
+// @Accept json
+// @Produce json
+// @Param id path string true "Lesson ID"
+// @Success 200 {object} models.Lesson
+// @Router /v1/lesson/{id} [get]
+func GetLesson(c *fiber.Ctx) error {
+	// Catch lesson ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get lesson by ID.
+	lesson, err := db.GetLesson(id)
+	if err != nil {
+		// Return, if lesson not found.
+		return problem.Lookup(err, problem.CodeLessonNotFound, "lesson with the given ID is not found")
+	}
+
+	// Set signed attachment URLs.
+	SignLessonAttachments(c.UserContext(), &lesson)
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":  false,
+		"msg":    nil,
+		"lesson": lesson,
+	})
+}
+
+// GetLessonHTML func gets sanitized HTML of lesson content by given ID.
+// @Description Get lesson content rendered to sanitized HTML with table of contents.
+// @Summary get rendered lesson content
+// @Tags Lesson
+// @Accept json
+// @Produce json
+// @Param id path string true "Lesson ID"
+// @Success 200 {object} render.Document
+// @Success 304 {string} status "not modified"
+// @Router /v1/lesson/{id}/html [get]
+func GetLessonHTML(c *fiber.Ctx) error {
+	// Catch lesson ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get lesson by ID.
+	lesson, err := db.GetLesson(id)
+	if err != nil {
+		// Return, if lesson not found.
+		return problem.Lookup(err, problem.CodeLessonNotFound, "lesson with the given ID is not found")
+	}
+
+	// Content revision is the ETag, so unchanged content is not sent again.
+	etag := `"` + render.Revision(lesson.Format, lesson.Content) + `"`
+	c.Set(fiber.HeaderETag, etag)
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	// Render lesson content.
+	doc, err := render.Render(lesson.Format, lesson.Content)
+	if err != nil {
+		// Return status 500 and render error.
+		return problem.From(err)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":    false,
+		"msg":      nil,
+		"document": doc,
+	})
+}
@@ -0,0 +1,500 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/logging"
+	"opendavinci/media"
+	"opendavinci/metrics"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/storage"
+)
+
+// imageTypes is the list of content types accepted for course images.
+var imageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// attachmentTypes is the list of content types accepted for lesson attachments.
+var attachmentTypes = []string{
+	"image/jpeg", "image/png", "image/gif", "image/webp",
+	"application/pdf", "application/zip", "text/plain", "audio/mpeg", "video/mp4",
+}
+
+// UploadCourseImage func for uploads image of course by given ID.
+// @Description Upload image of course, multipart field "image".
+// @Summary upload course image
+// @Tags Course
+// @Accept mpfd
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param image formData file true "Course image"
+// @Success 200 {object} models.Course
+// @Security ApiKeyAuth
+// @Router /v1/course/{id}/image [post]
+func UploadCourseImage(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
+	}
+
+	// Checking, if now time greater than expiration from JWT.
+	if now > claims.Expires {
+		// Return status 401 and unauthorized error message.
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	// Catch course ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Checking, if course with given ID does exist.
+	course, err := db.GetCourse(id)
+	if err != nil {
+		// Return status 404 and course not found error.
+		return problem.Lookup(err, problem.CodeCourseNotFound, "course with this ID not found")
+	}
+
+	// Store uploaded file.
+	object, err := storeUpload(c, "image", "courses/"+id.String()+"/image/",
+		mediaLimit(config.Get().Media.MaxImageSizeMB), imageTypes, true)
+	if err != nil {
+		return err
+	}
+
+	// Generate variants now, unless they are generated on first request.
+	// The course keeps its image, if the new one cannot be resized.
+	keys := []string{object.Key}
+	if !config.Get().Media.VariantsLazy {
+		for _, v := range media.Variants {
+			key, err := generateVariant(c.UserContext(), object.Key, v)
+			if err != nil {
+				removeBlobs(c, keys...)
+				// Return status 422, if image cannot be resized.
+				return problem.Wrap(err, fiber.StatusUnprocessableEntity, problem.CodeMediaInvalid, "image cannot be resized")
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	// Set image of course.
+	if err := db.UpdateCourseImage(course.ID, object.Key); err != nil {
+		removeBlobs(c, keys...)
+		// Return status 500 and error message.
+		return problem.From(err)
+	}
+
+	// The previous image and its variants are not used anymore.
+	if isBlobKey(course.Image) && course.Image != object.Key {
+		removeBlobs(c, imageBlobs(course.Image)...)
+	}
+	course.Image = object.Key
+	metrics.MediaUploads.WithLabelValues("image").Inc()
+	metrics.MediaUploadBytes.WithLabelValues("image").Add(float64(object.Size))
+
+	SignCourseImage(c.UserContext(), &course)
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":  false,
+		"msg":    nil,
+		"course": course,
+	})
+}
+
+// UploadLessonAttachment func for uploads attachment of lesson by given ID.
+// @Description Upload attachment of lesson, multipart field "file".
+// @Summary upload lesson attachment
+// @Tags Lesson
+// @Accept mpfd
+// @Produce json
+// @Param id path string true "Lesson ID"
+// @Param file formData file true "Lesson attachment"
+// @Success 200 {object} models.Attachment
+// @Security ApiKeyAuth
+// @Router /v1/lesson/{id}/attachment [post]
+func UploadLessonAttachment(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 401 and JWT parse error.
+		return problem.From(err)
+	}
+
+	// Checking, if now time greater than expiration from JWT.
+	if now > claims.Expires {
+		// Return status 401 and unauthorized error message.
+		return problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	// Catch lesson ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Checking, if lesson with given ID does exist.
+	lesson, err := db.GetLesson(id)
+	if err != nil {
+		// Return status 404 and lesson not found error.
+		return problem.Lookup(err, problem.CodeLessonNotFound, "lesson with this ID not found")
+	}
+
+	// Store uploaded file.
+	object, err := storeUpload(c, "file", "lessons/"+id.String()+"/attachments/",
+		mediaLimit(config.Get().Media.MaxAttachmentSizeMB), attachmentTypes, false)
+	if err != nil {
+		return err
+	}
+
+	attachment := &models.Attachment{
+		Key:         object.Key,
+		Name:        object.Name,
+		ContentType: object.ContentType,
+		Size:        object.Size,
+		Uploaded:    time.Now(),
+	}
+
+	// Append attachment to lesson.
+	if err := db.AddLessonAttachment(lesson.ID, attachment); err != nil {
+		// Return status 500 and error message.
+		return problem.From(err)
+	}
+	attachment.URL = signedMediaURL(c.UserContext(), attachment.Key)
+	metrics.MediaUploads.WithLabelValues("attachment").Inc()
+	metrics.MediaUploadBytes.WithLabelValues("attachment").Add(float64(object.Size))
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":      false,
+		"msg":        nil,
+		"attachment": attachment,
+	})
+}
+
+// GetCourseImageVariant func for redirects to resized image of course by given ID.
+// Missing variants are generated and stored on first request.
+// @Description Redirect to resized image of course.
+// @Summary get course image variant
+// @Tags Course
+// @Param id path string true "Course ID"
+// @Param variant path string true "Variant name" Enums(thumbnail, card, hero)
+// @Success 302 {string} status "found"
+// @Router /v1/course/{id}/image/{variant} [get]
+func GetCourseImageVariant(c *fiber.Ctx) error {
+	// Catch course ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Check, if variant is known.
+	v, ok := media.FindVariant(c.Params("variant"))
+	if !ok {
+		return problem.NotFound(problem.CodeVariantNotFound, "image variant is not found")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get course by ID.
+	course, err := db.GetCourse(id)
+	if err != nil {
+		// Return, if course not found.
+		return problem.Lookup(err, problem.CodeCourseNotFound, "course with the given ID is not found")
+	}
+	if !isBlobKey(course.Image) {
+		// Return, if course has no uploaded image.
+		return problem.NotFound(problem.CodeImageNotFound, "course with the given ID has no uploaded image")
+	}
+
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		return problem.From(err)
+	}
+
+	// Generate variant, if it is not cached in blob store yet.
+	key := media.VariantKey(course.Image, v, media.VariantFormat())
+	if _, err := store.Stat(c.UserContext(), key); err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return problem.From(err)
+		}
+		if key, err = generateVariant(c.UserContext(), course.Image, v); err != nil {
+			return problem.Wrap(err, fiber.StatusUnprocessableEntity, problem.CodeMediaInvalid, "image cannot be resized")
+		}
+	}
+
+	url := signedMediaURL(c.UserContext(), key)
+	if url == "" {
+		return problem.Internal(errors.New("download link cannot be signed"))
+	}
+
+	// Signed URL expires, so clients should not keep the redirect for long.
+	c.Set(fiber.HeaderCacheControl, "private, max-age=60")
+
+	return c.Redirect(url, fiber.StatusFound)
+}
+
+// GetMedia func for downloads blob by signed URL from the local blob store.
+// @Description Download uploaded media by signed URL.
+// @Summary download media
+// @Tags Media
+// @Produce octet-stream
+// @Param key path string true "Blob key"
+// @Param expires query int true "Expiration time"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file
+// @Router /v1/media/{key} [get]
+func GetMedia(c *fiber.Ctx) error {
+	// Open blob store.
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		return problem.From(err)
+	}
+
+	// Only local store is served by the app, other stores sign their own URLs.
+	local, ok := store.(*storage.LocalStore)
+	if !ok {
+		return problem.NotFound(problem.CodeRouteNotFound, "media is not served by this endpoint")
+	}
+
+	// Check, if download link is valid.
+	key := c.Params("*")
+	if err := local.VerifySignature(key, c.Query("expires"), c.Query("signature")); err != nil {
+		return problem.Wrap(err, fiber.StatusForbidden, problem.CodeSignatureInvalid, err.Error())
+	}
+
+	r, object, err := local.Open(c.UserContext(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return problem.NotFound(problem.CodeMediaNotFound, "media with this key not found")
+		}
+		return problem.From(err)
+	}
+
+	c.Set(fiber.HeaderContentType, object.ContentType)
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(int(mediaURLTTL().Seconds())))
+	c.Set("X-Content-Type-Options", "nosniff")
+
+	// Body stream is closed by fasthttp once response is written.
+	return c.SendStream(r, int(object.Size))
+}
+
+// SignCourseImage func for setting the signed image URL and variant URLs of course.
+func SignCourseImage(ctx context.Context, course *models.Course) {
+	image := strings.TrimSpace(course.Image)
+	switch {
+	case image == "":
+		course.ImageURL = ""
+	case !isBlobKey(image):
+		// External images are linked as is.
+		course.ImageURL = image
+	default:
+		course.ImageURL = signedMediaURL(ctx, image)
+
+		// Variant URLs are stable and redirect to the signed URL of the variant.
+		course.ImageVariants = make(map[string]string, len(media.Variants))
+		for _, v := range media.Variants {
+			course.ImageVariants[v.Name] = "/api/v1/course/" + course.ID.String() + "/image/" + v.Name
+		}
+	}
+}
+
+// SignLessonAttachments func for setting the signed URLs of lesson attachments.
+func SignLessonAttachments(ctx context.Context, lesson *models.Lesson) {
+	for i := range lesson.Attachments {
+		lesson.Attachments[i].URL = signedMediaURL(ctx, lesson.Attachments[i].Key)
+	}
+}
+
+// variantGroup deduplicates concurrent generation of the same variant.
+var variantGroup singleflight.Group
+
+// generateVariant func for resizing the original image and storing the variant.
+func generateVariant(ctx context.Context, original string, v media.Variant) (string, error) {
+	key, err, _ := variantGroup.Do(original+"#"+v.Name, func() (interface{}, error) {
+		store, err := storage.OpenBlobStore()
+		if err != nil {
+			return "", err
+		}
+
+		return media.Generate(ctx, store, original, v)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return key.(string), nil
+}
+
+// removeBlobs func for deleting blobs of a rejected or replaced upload, failures are only logged.
+func removeBlobs(c *fiber.Ctx, keys ...string) {
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		logging.Ctx(c).Warn("Blobs not deleted", "keys", keys, "error", err)
+		return
+	}
+
+	for _, key := range keys {
+		if err := store.Delete(c.UserContext(), key); err != nil {
+			logging.Ctx(c).Warn("Blob not deleted", "key", key, "error", err)
+		}
+	}
+}
+
+// imageBlobs func for getting the keys of an image and its variants in any format,
+// variants which were never generated are missing.
+func imageBlobs(image string) []string {
+	keys := []string{image}
+	for _, v := range media.Variants {
+		keys = append(keys,
+			media.VariantKey(image, v, "image/jpeg"),
+			media.VariantKey(image, v, "image/webp"),
+		)
+	}
+
+	return keys
+}
+
+// isBlobKey func for checking, if image refers to an uploaded blob.
+func isBlobKey(image string) bool {
+	image = strings.TrimSpace(image)
+
+	return image != "" && !strings.HasPrefix(image, "http://") && !strings.HasPrefix(image, "https://")
+}
+
+// uploadedObject struct to describe a stored upload.
+type uploadedObject struct {
+	storage.Object
+	Name string
+}
+
+// storeUpload func for checking and storing the multipart file in field.
+// Set strip to remove EXIF and other metadata from images before storing.
+// It returns a problem to send when the upload is rejected.
+func storeUpload(c *fiber.Ctx, field, prefix string, limit int64, allowed []string, strip bool) (*uploadedObject, error) {
+	file, err := c.FormFile(field)
+	if err != nil {
+		return nil, problem.Wrap(err, fiber.StatusBadRequest, problem.CodeBadRequest, "multipart field "+field+" is required")
+	}
+
+	// Check size before reading the file.
+	if file.Size > limit {
+		return nil, problem.New(fiber.StatusRequestEntityTooLarge, problem.CodeTooLarge,
+			"file is larger than "+strconv.FormatInt(limit>>20, 10)+" MB")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, problem.Wrap(err, fiber.StatusBadRequest, problem.CodeBadRequest, "file cannot be read")
+	}
+	defer f.Close()
+
+	// Trust the content, not the client provided Content-Type header.
+	contentType, r, err := storage.Sniff(f)
+	if err != nil {
+		return nil, problem.Wrap(err, fiber.StatusBadRequest, problem.CodeBadRequest, "file cannot be read")
+	}
+
+	if !slices.Contains(allowed, contentType) {
+		return nil, problem.New(fiber.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType,
+			"content type "+contentType+" is not allowed")
+	}
+
+	size := file.Size
+	if strip {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, problem.Wrap(err, fiber.StatusBadRequest, problem.CodeBadRequest, "file cannot be read")
+		}
+		if data, err = media.StripMetadata(contentType, data); err != nil {
+			return nil, problem.Wrap(err, fiber.StatusBadRequest, problem.CodeMediaInvalid, err.Error())
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		return nil, problem.From(err)
+	}
+
+	key := prefix + uuid.New().String() + storage.Extension(contentType)
+	if err := store.Put(c.UserContext(), key, r, contentType); err != nil {
+		return nil, problem.From(err)
+	}
+
+	return &uploadedObject{
+		Object: storage.Object{Key: key, ContentType: contentType, Size: size},
+		Name:   path.Base(file.Filename),
+	}, nil
+}
+
+// signedMediaURL func for building the download URL of the blob.
+// It returns an empty string, if URL cannot be signed.
+func signedMediaURL(ctx context.Context, key string) string {
+	store, err := storage.OpenBlobStore()
+	if err != nil {
+		return ""
+	}
+
+	url, err := store.SignedURL(ctx, key, mediaURLTTL())
+	if err != nil {
+		return ""
+	}
+
+	return url
+}
+
+// mediaURLTTL func for getting the lifetime of signed download URLs.
+func mediaURLTTL() time.Duration {
+	return config.Get().Media.URLExpire
+}
+
+// mediaLimit func for getting upload size limit in bytes.
+func mediaLimit(mb int) int64 {
+	return int64(mb) << 20
+}
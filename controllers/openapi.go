@@ -0,0 +1,364 @@
+package controllers
+
+import (
+	"github.com/google/uuid"
+
+	"opendavinci/cartridge"
+	"opendavinci/graph"
+	"opendavinci/health"
+	"opendavinci/media"
+	"opendavinci/models"
+	"opendavinci/openapi"
+	"opendavinci/problem"
+)
+
+// PackageImportResponse struct to describe the outcome of a package import.
+type PackageImportResponse struct {
+	Error       bool                    `json:"error"`
+	Msg         *string                 `json:"msg"`
+	DryRun      bool                    `json:"dryRun"`
+	Result      *models.ImportResult    `json:"result"`
+	Files       int                     `json:"files"`
+	Unsupported []cartridge.Unsupported `json:"unsupported"`
+}
+
+// Operations of handlers for the OpenAPI document, routes are taken from the app.
+func init() {
+	courseID := openapi.Path("id", "Course ID", uuid.UUID{})
+	lessonID := openapi.Path("id", "Lesson ID", uuid.UUID{})
+	userID := openapi.Path("id", "User ID", uuid.UUID{})
+	dryRun := openapi.Query("dryRun", "Validate and report without saving", false)
+	idempotencyKey := openapi.Param{Name: "Idempotency-Key", In: "header", Description: "Unique key of the request, retries with the same key get the first response for 24 hours", Type: ""}
+
+	variants := make([]string, 0, len(media.Variants))
+	for _, v := range media.Variants {
+		variants = append(variants, v.Name)
+	}
+
+	// Courses.
+	openapi.Describe(GetCourses, openapi.Operation{
+		Summary: "get all exists courses",
+		Tags:    []string{"Course"},
+		Params: []openapi.Param{
+			openapi.Query("limit", "Courses per page, ordered by creation, default is all", 0),
+			openapi.Query("cursor", "Cursor of the page, nextCursor of the previous page", ""),
+		},
+		Responses: []openapi.Response{{Status: 200, Model: models.CoursesResponse{}}},
+	})
+	openapi.Describe(GetCourse, openapi.Operation{
+		Summary:   "get course by given ID",
+		Tags:      []string{"Course"},
+		Params:    []openapi.Param{courseID},
+		Responses: []openapi.Response{{Status: 200, Model: models.CourseResponse{}}},
+	})
+	openapi.Describe(CreateCourse, openapi.Operation{
+		Summary:   "create a new course",
+		Tags:      []string{"Course"},
+		Params:    []openapi.Param{idempotencyKey},
+		Body:      &openapi.Body{Model: models.Course{}, Omit: []string{"id", "created", "imageUrl", "imageVariants"}},
+		Responses: []openapi.Response{{Status: 200, Model: models.CourseResponse{}}},
+	})
+	openapi.Describe(UpdateCourse, openapi.Operation{
+		Summary:   "update course",
+		Tags:      []string{"Course"},
+		Body:      &openapi.Body{Model: models.Course{}, Omit: []string{"created", "imageUrl", "imageVariants"}},
+		Responses: []openapi.Response{{Status: 201, Description: "Updated"}},
+	})
+	openapi.Describe(DeleteCourse, openapi.Operation{
+		Summary:   "delete course by given ID",
+		Tags:      []string{"Course"},
+		Body:      &openapi.Body{Model: models.Course{}, Only: []string{"id"}},
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+	})
+
+	// Lessons.
+	openapi.Describe(GetLesson, openapi.Operation{
+		Summary:   "get lesson by given ID",
+		Tags:      []string{"Lesson"},
+		Params:    []openapi.Param{lessonID},
+		Responses: []openapi.Response{{Status: 200, Model: models.LessonResponse{}}},
+	})
+	openapi.Describe(GetLessonHTML, openapi.Operation{
+		Summary:     "get rendered lesson content",
+		Description: "Get lesson content rendered to sanitized HTML with table of contents.",
+		Tags:        []string{"Lesson"},
+		Params:      []openapi.Param{lessonID},
+		Responses: []openapi.Response{
+			{Status: 200, Model: models.DocumentResponse{}, Headers: []string{"ETag"}},
+			{Status: 304, Description: "Content is not modified"},
+		},
+	})
+
+	// Media.
+	openapi.Describe(UploadCourseImage, openapi.Operation{
+		Summary:   "upload course image",
+		Tags:      []string{"Course"},
+		Params:    []openapi.Param{courseID, idempotencyKey},
+		Body:      openapi.File("image", "Course image"),
+		Responses: []openapi.Response{{Status: 200, Model: models.CourseResponse{}}},
+	})
+	openapi.Describe(UploadLessonAttachment, openapi.Operation{
+		Summary:   "upload lesson attachment",
+		Tags:      []string{"Lesson"},
+		Params:    []openapi.Param{lessonID, idempotencyKey},
+		Body:      openapi.File("file", "Lesson attachment"),
+		Responses: []openapi.Response{{Status: 200, Model: models.AttachmentResponse{}}},
+	})
+	openapi.Describe(GetCourseImageVariant, openapi.Operation{
+		Summary: "get course image variant",
+		Tags:    []string{"Course"},
+		Params: []openapi.Param{
+			courseID,
+			{Name: "variant", In: "path", Description: "Variant name", Required: true, Enum: variants},
+		},
+		Responses: []openapi.Response{{Status: 302, Description: "Redirect to signed URL of the variant", Headers: []string{"Location"}}},
+	})
+	openapi.Describe(GetMedia, openapi.Operation{
+		Summary: "download media",
+		Tags:    []string{"Media"},
+		Params: []openapi.Param{
+			openapi.Path("key", "Blob key", ""),
+			{Name: "expires", In: "query", Description: "Expiration time", Required: true, Type: 0},
+			{Name: "signature", In: "query", Description: "URL signature", Required: true},
+		},
+		Responses: []openapi.Response{{Status: 200, ContentType: "application/octet-stream", Model: []byte{}}},
+	})
+
+	// Token.
+	openapi.Describe(GetNewAccessToken, openapi.Operation{
+		Summary:     "create a new access token",
+		Description: "With the API key of a user the token has the user as subject and its role, e.g. admin. Without one it has neither.",
+		Tags:        []string{"Token"},
+		Params:      []openapi.Param{{Name: HeaderAPIKey, In: "header", Description: "API key of a user", Type: ""}},
+		Responses:   []openapi.Response{{Status: 200, Model: models.TokenResponse{}}},
+	})
+
+	// Admin.
+	openapi.Describe(ImportCatalog, openapi.Operation{
+		Summary:     "import course catalog",
+		Description: "Upsert courses and lessons by courseId and lessonId. Body is NDJSON with one course per line, or a zip archive of .json and .ndjson files.",
+		Tags:        []string{"Admin"},
+		Params:      []openapi.Param{dryRun, idempotencyKey},
+		Body: &openapi.Body{
+			ContentType: "application/x-ndjson",
+			Description: "One course with its lessons per line",
+			Model:       models.CatalogCourse{},
+		},
+		Responses: []openapi.Response{{Status: 200, Model: models.ImportResponse{}}},
+	})
+	openapi.Describe(ExportCatalog, openapi.Operation{
+		Summary:     "export course catalog",
+		Description: "Stream all courses with their lessons, one course per NDJSON line or one courses/{courseId}.json file per course in a zip archive.",
+		Tags:        []string{"Admin"},
+		Params: []openapi.Param{
+			{Name: "format", In: "query", Description: "Export format", Enum: []string{"ndjson", "zip"}},
+		},
+		Responses: []openapi.Response{
+			{Status: 200, ContentType: "application/x-ndjson", Model: models.CatalogCourse{}},
+		},
+	})
+	openapi.Describe(ImportPackage, openapi.Operation{
+		Summary:     "import Common Cartridge or SCORM package",
+		Description: "Create or update a course with ordered lessons from imsmanifest.xml of the uploaded zip package. Package elements which were not imported are listed in the response, so are package files which were not stored, importing again stores them.",
+		Tags:        []string{"Admin"},
+		Params: []openapi.Param{
+			openapi.Query("courseId", "Course ID, default is the manifest identifier", ""),
+			dryRun,
+		},
+		Body:      openapi.File("package", "Package zip file"),
+		Responses: []openapi.Response{{Status: 200, Model: PackageImportResponse{}}},
+	})
+
+	// Webhooks.
+	webhookID := openapi.Path("id", "Webhook ID", uuid.UUID{})
+	deliveryID := openapi.Path("id", "Delivery ID", uuid.UUID{})
+	openapi.Describe(GetWebhooks, openapi.Operation{
+		Summary:   "get all webhooks",
+		Tags:      []string{"Webhooks"},
+		Responses: []openapi.Response{{Status: 200, Model: models.WebhooksResponse{}}},
+	})
+	openapi.Describe(GetWebhook, openapi.Operation{
+		Summary:   "get webhook by given ID",
+		Tags:      []string{"Webhooks"},
+		Params:    []openapi.Param{webhookID},
+		Responses: []openapi.Response{{Status: 200, Model: models.WebhookResponse{}}},
+	})
+	openapi.Describe(CreateWebhook, openapi.Operation{
+		Summary:     "create a new webhook",
+		Description: "Events are course.published and enrollment.completed. Without a secret a random one is created, it is sent only in this response. Deliveries are signed with it in X-OpenDaVinci-Signature: t=<unix time>,v1=<hex HMAC-SHA256 of \"<t>.<body>\">.",
+		Tags:        []string{"Webhooks"},
+		Params:      []openapi.Param{idempotencyKey},
+		Body:        &openapi.Body{Model: models.Webhook{}, Omit: []string{"id", "created"}},
+		Responses:   []openapi.Response{{Status: 200, Model: models.WebhookResponse{}}},
+	})
+	openapi.Describe(UpdateWebhook, openapi.Operation{
+		Summary:     "update webhook",
+		Description: "The secret is kept, unless a new one is sent.",
+		Tags:        []string{"Webhooks"},
+		Params:      []openapi.Param{webhookID},
+		Body:        &openapi.Body{Model: models.Webhook{}, Omit: []string{"id", "created"}},
+		Responses:   []openapi.Response{{Status: 201, Description: "Updated"}},
+	})
+	openapi.Describe(DeleteWebhook, openapi.Operation{
+		Summary:   "delete webhook by given ID",
+		Tags:      []string{"Webhooks"},
+		Params:    []openapi.Param{webhookID},
+		Responses: []openapi.Response{{Status: 204, Description: "Deleted"}},
+	})
+	openapi.Describe(PingWebhook, openapi.Operation{
+		Summary:     "send a ping event to webhook",
+		Description: "Queue a delivery of a ping event, e.g. to test a receiver. It is recorded in the delivery history.",
+		Tags:        []string{"Webhooks"},
+		Params:      []openapi.Param{webhookID, idempotencyKey},
+		Responses:   []openapi.Response{{Status: 202, Model: models.DeliveryResponse{}}},
+	})
+	openapi.Describe(GetWebhookDeliveries, openapi.Operation{
+		Summary:   "get delivery history of webhook",
+		Tags:      []string{"Webhooks"},
+		Params:    []openapi.Param{webhookID, openapi.Query("limit", "Number of deliveries, newest first, default is 50", 0)},
+		Responses: []openapi.Response{{Status: 200, Model: models.DeliveriesResponse{}}},
+	})
+	openapi.Describe(GetWebhookDelivery, openapi.Operation{
+		Summary:   "get delivery with its attempts",
+		Tags:      []string{"Webhooks"},
+		Params:    []openapi.Param{deliveryID},
+		Responses: []openapi.Response{{Status: 200, Model: models.DeliveryResponse{}}},
+	})
+	openapi.Describe(RedeliverWebhookDelivery, openapi.Operation{
+		Summary:     "redeliver the event of a delivery",
+		Description: "Queue a new delivery with the payload and event ID of the given one, whatever its status.",
+		Tags:        []string{"Webhooks"},
+		Params:      []openapi.Param{deliveryID, idempotencyKey},
+		Responses:   []openapi.Response{{Status: 202, Model: models.DeliveryResponse{}}},
+	})
+
+	// Jobs.
+	jobID := openapi.Path("id", "Job ID", int64(0))
+	openapi.Describe(GetJobs, openapi.Operation{
+		Summary:     "get background jobs",
+		Description: "Dead jobs failed all their attempts, they are kept until they are retried.",
+		Tags:        []string{"Jobs"},
+		Params: []openapi.Param{
+			openapi.Query("status", "Status: pending, running, done or dead, default is dead", ""),
+			openapi.Query("kind", "Kind, e.g. webhook.deliver, default is all", ""),
+			openapi.Query("limit", "Number of jobs, newest first, default is 50", 0),
+		},
+		Responses: []openapi.Response{{Status: 200, Model: models.JobsResponse{}}},
+	})
+	openapi.Describe(GetJob, openapi.Operation{
+		Summary:   "get background job by given ID",
+		Tags:      []string{"Jobs"},
+		Params:    []openapi.Param{jobID},
+		Responses: []openapi.Response{{Status: 200, Model: models.JobResponse{}}},
+	})
+	openapi.Describe(RetryJob, openapi.Operation{
+		Summary:     "retry dead job",
+		Description: "Queue a dead job again with all its attempts.",
+		Tags:        []string{"Jobs"},
+		Params:      []openapi.Param{jobID, idempotencyKey},
+		Responses:   []openapi.Response{{Status: 202, Model: models.JobResponse{}}},
+	})
+
+	// User.
+	openapi.Describe(GetCurrentUser, openapi.Operation{
+		Summary:     "get current user",
+		Description: "Get the user of the access token, tokens issued for an API key have one.",
+		Tags:        []string{"User"},
+		Responses:   []openapi.Response{{Status: 200, Model: models.UserResponse{}}},
+	})
+	openapi.Describe(GetUser, openapi.Operation{
+		Summary:     "get user by given ID",
+		Description: "It needs a token of the user or with the admin role.",
+		Tags:        []string{"User"},
+		Params:      []openapi.Param{userID},
+		Responses:   []openapi.Response{{Status: 200, Model: models.UserResponse{}}},
+	})
+
+	// Audit.
+	openapi.Describe(GetAuditLog, openapi.Operation{
+		Summary:     "get audit log",
+		Description: "Changes of courses, lessons, enrollments, users and webhooks, auth events and admin actions with their actor, request ID and IP. Updates keep only the changed fields in before and after, secrets are redacted. It needs a token with the admin role.",
+		Tags:        []string{"Audit"},
+		Params: []openapi.Param{
+			openapi.Query("actor", "Actor, e.g. user:<id>, token:<hash>, anonymous or system", ""),
+			openapi.Query("resourceType", "Resource type, e.g. course, webhook or token", ""),
+			openapi.Query("resourceId", "Resource ID, with resourceType", ""),
+			openapi.Query("from", "Entries created at or after, RFC 3339", ""),
+			openapi.Query("to", "Entries created before, RFC 3339", ""),
+			openapi.Query("cursor", "Cursor of the page, nextCursor of the previous page", ""),
+			openapi.Query("limit", "Number of entries, newest first, default is 50", 0),
+		},
+		Responses: []openapi.Response{{Status: 200, Model: models.AuditLogResponse{}}},
+	})
+	openapi.Describe(VerifyAuditLog, openapi.Operation{
+		Summary:     "verify audit log",
+		Description: "Walk the hash chain of entries recorded with AUDIT_HASH_CHAIN and report the first entry which was changed, removed or reordered. It needs a token with the admin role.",
+		Tags:        []string{"Audit"},
+		Responses:   []openapi.Response{{Status: 200, Model: models.AuditVerifyResponse{}}},
+	})
+
+	// Events.
+	openapi.Describe(GetEvents, openapi.Operation{
+		Summary:     "stream changes of courses and lessons",
+		Description: "Server-Sent Events named type.action, e.g. course.updated, with the event as data. Clients which reconnect with Last-Event-ID get the events they missed first.",
+		Tags:        []string{"Events"},
+		Params: []openapi.Param{
+			openapi.Query("types", "Resource types, comma separated: course, lesson. Default is all", ""),
+			{Name: "Last-Event-ID", In: "header", Description: "ID of the last event received", Type: int64(0)},
+			openapi.Query("lastEventId", "Last-Event-ID for clients which cannot set headers", int64(0)),
+		},
+		Responses: []openapi.Response{
+			{Status: 200, ContentType: "text/event-stream", Model: models.Event{}},
+			{Status: 503, Description: "Too many streams, retry later", ContentType: problem.ContentType, Model: problem.Problem{}, Headers: []string{"Retry-After"}},
+		},
+	})
+
+	// GraphQL.
+	openapi.Describe(GraphQL, openapi.Operation{
+		Summary:     "run a GraphQL query",
+		Description: "Query courses, lessons, users and enrollments. The access token is optional, private fields need it. Errors of fields are sent in errors next to data.",
+		Tags:        []string{"GraphQL"},
+		Body:        &openapi.Body{Model: graph.Request{}},
+		Responses: []openapi.Response{
+			{Status: 200, Model: map[string]any{}},
+			{Status: 400, Description: "Query is invalid or exceeds the limits", Model: map[string]any{}},
+		},
+	})
+
+	// Health.
+	openapi.Describe(Healthz, openapi.Operation{
+		Summary:   "liveness probe",
+		Tags:      []string{"Health"},
+		Responses: []openapi.Response{{Status: 200, Model: health.Report{}}},
+	})
+	openapi.Describe(Readyz, openapi.Operation{
+		Summary:   "readiness probe",
+		Tags:      []string{"Health"},
+		Responses: []openapi.Response{{Status: 200, Model: health.Report{}}, {Status: 503, Model: health.Report{}}},
+	})
+	openapi.Describe(Startupz, openapi.Operation{
+		Summary:   "startup probe",
+		Tags:      []string{"Health"},
+		Responses: []openapi.Response{{Status: 200, Model: health.Report{}}, {Status: 503, Model: health.Report{}}},
+	})
+
+	// Legacy, pre-v1 API.
+	openapi.Describe(LegacyGetCourses, openapi.Operation{
+		Summary:    "get all courses",
+		Tags:       []string{"Legacy"},
+		Deprecated: true,
+		Responses:  []openapi.Response{{Status: 200, Model: []models.Course{}}},
+	})
+	openapi.Describe(LegacyCreateCourse, openapi.Operation{
+		Summary:    "create a course from raw JSON",
+		Tags:       []string{"Legacy"},
+		Deprecated: true,
+		Body:       &openapi.Body{Model: map[string]any{}},
+	})
+	openapi.Describe(LegacyGetLessons, openapi.Operation{
+		Summary:    "show request headers",
+		Tags:       []string{"Legacy"},
+		Deprecated: true,
+		Responses:  []openapi.Response{{Status: 200, Model: map[string][]string{}}},
+	})
+}
@@ -1,31 +1,87 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/audit"
+	"opendavinci/database"
+	"opendavinci/metrics"
+	"opendavinci/models"
+	"opendavinci/problem"
 )
 
+// HeaderAPIKey is the header of the API key of a user, see GetNewAccessToken.
+const HeaderAPIKey = "X-API-Key"
+
 // GetNewAccessToken method for create a new access token.
+// With the API key of a user in X-API-Key the token has the user as subject and its role,
+// otherwise it has neither.
 // @Description Create a new access token.
 // @Summary create a new access token
 // @Tags Token
 // @Accept json
 // @Produce json
+// @Param X-API-Key header string false "API key of a user"
 // @Success 200 {string} status "ok"
 // @Router /v1/token/new [get]
 func GetNewAccessToken(c *fiber.Ctx) error {
-	// Generate a new Access token.
-	token, err := GenerateNewAccessToken()
+	var (
+		token   string
+		details map[string]any
+		err     error
+	)
+	if key := c.Get(HeaderAPIKey); key != "" {
+		user, uerr := userOfAPIKey(c, key)
+		if uerr != nil {
+			return uerr
+		}
+		details = map[string]any{"userId": user.ID, "role": user.Role}
+
+		// Generate a new Access token of the user.
+		token, err = GenerateUserAccessToken(user)
+	} else {
+		// Generate a new Access token.
+		token, err = GenerateNewAccessToken()
+	}
 	if err != nil {
 		// Return status 500 and token generation error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		return problem.From(err)
 	}
 
+	metrics.TokensIssued.Inc()
+	recordAudit(c, "auth.token_issued", "token", audit.TokenID(token), details)
+
 	return c.JSON(fiber.Map{
 		"error":        false,
 		"msg":          nil,
 		"access_token": token,
 	})
 }
+
+// userOfAPIKey func for getting the user of an API key, unknown keys get 401.
+func userOfAPIKey(c *fiber.Ctx, key string) (models.User, error) {
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return models.User{}, problem.From(err)
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	user, err := db.GetUserByAPIKeyHash(hex.EncodeToString(sum[:]))
+	if errors.Is(err, sql.ErrNoRows) {
+		// Return status 401, if no user has the key.
+		return models.User{}, problem.Unauthorized(problem.CodeUnauthorized, "API key is not valid")
+	}
+	if err != nil {
+		// Return status 500 and database error.
+		return models.User{}, problem.From(err)
+	}
+
+	return user, nil
+}
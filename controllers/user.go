@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"opendavinci/database"
+	"opendavinci/models"
+	"opendavinci/problem"
+)
+
+// GetCurrentUser func gets the user of the access token.
+// @Description Get the user of the access token, tokens of an API key have one.
+// @Summary get current user
+// @Tags User
+// @Produce json
+// @Success 200 {object} models.User
+// @Security ApiKeyAuth
+// @Router /v1/user/me [get]
+func GetCurrentUser(c *fiber.Ctx) error {
+	claims, err := authorize(c)
+	if err != nil {
+		return err
+	}
+
+	// Tokens of GetNewAccessToken without API key have no user.
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		// Return status 403 and forbidden error.
+		return problem.New(fiber.StatusForbidden, problem.CodeForbidden, "access token has no user")
+	}
+
+	return sendUser(c, id)
+}
+
+// GetUser func gets user by given ID, for the user and admins only.
+// @Description Get user by given ID, for the user and admins only.
+// @Summary get user by given ID
+// @Tags User
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User
+// @Security ApiKeyAuth
+// @Router /v1/user/{id} [get]
+func GetUser(c *fiber.Ctx) error {
+	claims, err := authorize(c)
+	if err != nil {
+		return err
+	}
+
+	// Catch user ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Checking, if the token is of the user or an admin.
+	if claims.Subject != id.String() && claims.Role != models.RoleAdmin {
+		// Return status 403 and forbidden error.
+		return problem.New(fiber.StatusForbidden, problem.CodeForbidden, "user is visible to the user and admins only")
+	}
+
+	return sendUser(c, id)
+}
+
+// sendUser func for sending the user with id or 404 error.
+func sendUser(c *fiber.Ctx, id uuid.UUID) error {
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get user by ID.
+	user, err := db.GetUser(id)
+	if err != nil {
+		// Return, if user not found.
+		return problem.Lookup(err, problem.CodeUserNotFound, "user with the given ID is not found")
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"user":  user,
+	})
+}
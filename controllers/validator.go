@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"errors"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
@@ -12,6 +14,10 @@ func NewValidator() *validator.Validate {
 
 	// Custom validation for uuid.UUID fields.
 	_ = validate.RegisterValidation("uuid", func(fl validator.FieldLevel) bool {
+		// String of a uuid.UUID value is not its text, it is an array.
+		if id, ok := fl.Field().Interface().(uuid.UUID); ok {
+			return id != uuid.Nil
+		}
 		field := fl.Field().String()
 		if _, err := uuid.Parse(field); err != nil {
 			return false // if there is an error, validation should return false
@@ -27,8 +33,15 @@ func ValidatorErrors(err error) map[string]string {
 	// Define fields map.
 	fields := map[string]string{}
 
+	// Other errors are kept as one message.
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		fields["error"] = err.Error()
+		return fields
+	}
+
 	// Make error message for each invalid field.
-	for _, err := range err.(validator.ValidationErrors) {
+	for _, err := range verrs {
 		fields[err.Field()] = err.Error()
 	}
 
@@ -0,0 +1,484 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"opendavinci/audit"
+	"opendavinci/database"
+	"opendavinci/jobs"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/webhooks"
+)
+
+// maxDeliveriesPerPage is the largest limit of GetWebhookDeliveries.
+const maxDeliveriesPerPage = 100
+
+// GetWebhooks func gets all webhooks, without their secrets.
+// @Description Get all webhooks.
+// @Summary get all webhooks
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} models.Webhook
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks [get]
+func GetWebhooks(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get all webhooks.
+	hooks, err := db.GetWebhooks()
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	// Secrets are sent only once, when a webhook is created.
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":    false,
+		"msg":      nil,
+		"count":    len(hooks),
+		"webhooks": hooks,
+	})
+}
+
+// GetWebhook func gets webhook by given ID, without its secret.
+// @Description Get webhook by given ID.
+// @Summary get webhook by given ID
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/{id} [get]
+func GetWebhook(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch webhook ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get webhook by ID.
+	hook, err := db.GetWebhook(id)
+	if err != nil {
+		// Return, if webhook not found.
+		return problem.Lookup(err, problem.CodeWebhookNotFound, "webhook with the given ID is not found")
+	}
+	hook.Secret = ""
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"msg":     nil,
+		"webhook": hook,
+	})
+}
+
+// CreateWebhook func for creates a new webhook.
+// Without a secret in the body, a random one is created. The secret is sent only in this response.
+// @Description Create a new webhook.
+// @Summary create a new webhook
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.Webhook true "Webhook JSON"
+// @Success 200 {object} models.Webhook
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks [post]
+func CreateWebhook(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Create new Webhook struct, active unless the body says otherwise.
+	hook := &models.Webhook{Active: true}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(hook); err != nil {
+		// Return status 400 and parse error.
+		return problem.InvalidBody(err)
+	}
+
+	// Set initialized default data for webhook:
+	hook.ID = uuid.New()
+	hook.Created = time.Now()
+	if hook.Secret == "" {
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			// Return status 500 and error message.
+			return problem.From(err)
+		}
+		hook.Secret = secret
+	}
+
+	// Validate webhook fields.
+	if err := NewValidator().Struct(hook); err != nil {
+		// Return, if some fields are not valid.
+		return problem.Validation(err)
+	}
+	if err := webhooks.CheckURL(c.UserContext(), hook.URL); err != nil {
+		// Return status 400, if the receiver is not public.
+		return problem.Wrap(err, fiber.StatusBadRequest, problem.CodeWebhookURLForbidden, err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	if err := db.CreateWebhook(hook); err != nil {
+		// Return status 500 and error message.
+		return problem.From(err)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":   false,
+		"msg":     nil,
+		"webhook": hook,
+	})
+}
+
+// UpdateWebhook func for updates webhook by given ID.
+// The secret is kept, unless the body has a new one.
+// @Description Update webhook.
+// @Summary update webhook
+// @Tags Webhooks
+// @Accept json
+// @Param id path string true "Webhook ID"
+// @Param webhook body models.Webhook true "Webhook JSON"
+// @Success 201 {string} status "ok"
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/{id} [put]
+func UpdateWebhook(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch webhook ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create new Webhook struct
+	hook := &models.Webhook{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(hook); err != nil {
+		// Return status 400 and parse error.
+		return problem.InvalidBody(err)
+	}
+
+	// Validate webhook fields.
+	if err := NewValidator().Struct(hook); err != nil {
+		// Return, if some fields are not valid.
+		return problem.Validation(err)
+	}
+	if err := webhooks.CheckURL(c.UserContext(), hook.URL); err != nil {
+		// Return status 400, if the receiver is not public.
+		return problem.Wrap(err, fiber.StatusBadRequest, problem.CodeWebhookURLForbidden, err.Error())
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Update webhook by given ID.
+	if err := db.UpdateWebhook(id, hook); err != nil {
+		// Return, if webhook not found.
+		return problem.Lookup(err, problem.CodeWebhookNotFound, "webhook with the given ID is not found")
+	}
+
+	// Return status 201.
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// DeleteWebhook func for deletes webhook by given ID with its delivery history.
+// @Description Delete webhook by given ID.
+// @Summary delete webhook by given ID
+// @Tags Webhooks
+// @Param id path string true "Webhook ID"
+// @Success 204 {string} status "ok"
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/{id} [delete]
+func DeleteWebhook(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch webhook ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Delete webhook by given ID.
+	if err := db.DeleteWebhook(id); err != nil {
+		// Return, if webhook not found.
+		return problem.Lookup(err, problem.CodeWebhookNotFound, "webhook with the given ID is not found")
+	}
+
+	// Return status 204 no content.
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PingWebhook func for sends a ping event to webhook by given ID, e.g. to test a receiver.
+// The delivery is queued like the ones of other events and recorded in the history.
+// @Description Send a ping event to webhook.
+// @Summary ping webhook
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 202 {object} models.Delivery
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/{id}/ping [post]
+func PingWebhook(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch webhook ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Checking, if webhook with given ID does exist.
+	hook, err := db.GetWebhook(id)
+	if err != nil {
+		// Return, if webhook not found.
+		return problem.Lookup(err, problem.CodeWebhookNotFound, "webhook with the given ID is not found")
+	}
+
+	delivery, err := webhooks.NewDelivery(hook.ID, webhooks.EventPing, fiber.Map{"webhookId": hook.ID, "events": hook.Events})
+	if err != nil {
+		// Return status 500 and error message.
+		return problem.From(err)
+	}
+
+	return queueDelivery(c, db, delivery, audit.NewEntry(c.UserContext(), "webhook.pinged", "webhook", hook.ID.String(),
+		map[string]any{"deliveryId": delivery.ID}))
+}
+
+// GetWebhookDeliveries func gets the delivery history of webhook by given ID, newest first.
+// @Description Get deliveries of webhook, newest first.
+// @Summary get deliveries of webhook
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Number of deliveries, default is 50"
+// @Success 200 {array} models.Delivery
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/{id}/deliveries [get]
+func GetWebhookDeliveries(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch webhook ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+	limit, err := strconv.Atoi(c.Query("limit", "50"))
+	if err != nil || limit < 1 || limit > maxDeliveriesPerPage {
+		// Return status 400 and invalid query error.
+		return problem.BadRequest(problem.CodeInvalidQuery, fmt.Sprintf("limit must be an integer from 1 to %d", maxDeliveriesPerPage))
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Checking, if webhook with given ID does exist.
+	if _, err := db.GetWebhook(id); err != nil {
+		// Return, if webhook not found.
+		return problem.Lookup(err, problem.CodeWebhookNotFound, "webhook with the given ID is not found")
+	}
+
+	deliveries, err := db.GetDeliveries(id, limit)
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":      false,
+		"msg":        nil,
+		"count":      len(deliveries),
+		"deliveries": deliveries,
+	})
+}
+
+// GetWebhookDelivery func gets delivery by given ID with all its attempts.
+// @Description Get delivery by given ID with its attempts.
+// @Summary get delivery with attempts
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 200 {object} models.Delivery
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/deliveries/{id} [get]
+func GetWebhookDelivery(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch delivery ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get delivery by ID.
+	delivery, err := db.GetDelivery(id)
+	if err != nil {
+		// Return, if delivery not found.
+		return problem.Lookup(err, problem.CodeDeliveryNotFound, "delivery with the given ID is not found")
+	}
+
+	attempts, err := db.GetDeliveryAttempts(id)
+	if err != nil {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	// Return status 200 OK.
+	return c.JSON(fiber.Map{
+		"error":    false,
+		"msg":      nil,
+		"delivery": delivery,
+		"attempts": attempts,
+	})
+}
+
+// RedeliverWebhookDelivery func for queues the event of delivery by given ID again,
+// as a new delivery with the same payload and event ID, whatever the status of the old one.
+// @Description Queue the event of a delivery again.
+// @Summary redeliver delivery
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} models.Delivery
+// @Security ApiKeyAuth
+// @Router /v1/admin/webhooks/deliveries/{id}/redeliver [post]
+func RedeliverWebhookDelivery(c *fiber.Ctx) error {
+	if _, err := authorize(c); err != nil {
+		return err
+	}
+
+	// Catch delivery ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		// Return status 400 and invalid ID error.
+		return problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err != nil {
+		// Return status 503 and database connection error.
+		return problem.From(err)
+	}
+
+	// Get delivery by ID.
+	delivery, err := db.GetDelivery(id)
+	if err != nil {
+		// Return, if delivery not found.
+		return problem.Lookup(err, problem.CodeDeliveryNotFound, "delivery with the given ID is not found")
+	}
+
+	redelivery := webhooks.Redelivery(delivery)
+
+	return queueDelivery(c, db, redelivery, audit.NewEntry(c.UserContext(), "delivery.redelivered", "delivery", delivery.ID.String(),
+		map[string]any{"deliveryId": redelivery.ID}))
+}
+
+// queueDelivery func for storing a new delivery with its job and audit entry e and waking the worker,
+// it answers with status 202.
+func queueDelivery(c *fiber.Ctx, db *database.Queries, delivery *models.Delivery, e *models.AuditEntry) error {
+	err := db.InTx(func(tx *database.Queries) error {
+		if err := webhooks.Enqueue(tx, delivery); err != nil {
+			return err
+		}
+		return tx.CreateAuditEntry(e)
+	})
+	if err != nil {
+		// Return status 500 and error message.
+		return problem.From(err)
+	}
+	jobs.Wake()
+
+	// Return status 202 accepted, the delivery is attempted by the worker.
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"error":    false,
+		"msg":      nil,
+		"delivery": delivery,
+	})
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Migrations are applied by golang-migrate, the app only reads their versions.
+//
+//go:embed migrations/*.up.sql
+var migrationFiles embed.FS
+
+// LatestMigration func for getting the version of the newest migration file.
+func LatestMigration() uint {
+	names, _ := migrationFiles.ReadDir("migrations")
+
+	var latest uint
+	for _, f := range names {
+		prefix, _, _ := strings.Cut(path.Base(f.Name()), "_")
+		if v, err := strconv.ParseUint(prefix, 10, 64); err == nil && uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+
+	return latest
+}
+
+// MigrationVersion func for getting the version applied to the database.
+// Dirty is true, if the last migration failed and needs manual fixing.
+func MigrationVersion(ctx context.Context) (version uint, dirty bool, err error) {
+	db, err := openPool()
+	if err != nil {
+		return 0, false, err
+	}
+
+	// golang-migrate keeps one row with the current version.
+	err = db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+// Ping func for checking the database can be reached.
+func Ping(ctx context.Context) error {
+	db, err := openPool()
+	if err != nil {
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
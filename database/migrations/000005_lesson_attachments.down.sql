@@ -0,0 +1,11 @@
+DROP VIEW IF EXISTS lessons_v;
+
+CREATE VIEW lessons_v AS
+SELECT
+    id, created,
+    rawdata ->> 'lessonid' AS lessonid,
+    rawdata ->> 'title' AS title,
+    rawdata ->> 'content' AS content,
+    rawdata ->> 'resourceurl' AS resourceurl
+
+FROM lessons;
@@ -0,0 +1,13 @@
+DROP VIEW IF EXISTS lessons_v;
+
+-- define views that extract from json, attachments stay a json array
+CREATE VIEW lessons_v AS
+SELECT
+    id, created,
+    COALESCE(rawdata ->> 'lessonid', '') AS lessonid,
+    COALESCE(rawdata ->> 'title', '') AS title,
+    COALESCE(rawdata ->> 'content', '') AS content,
+    COALESCE(rawdata ->> 'resourceurl', '') AS resourceurl,
+    COALESCE(rawdata -> 'attachments', '[]'::jsonb) AS attachments
+
+FROM lessons;
@@ -0,0 +1,14 @@
+DROP VIEW IF EXISTS lessons_v;
+
+-- lessons without a format keep their content as plain text
+CREATE VIEW lessons_v AS
+SELECT
+    id, created,
+    COALESCE(rawdata ->> 'lessonid', '') AS lessonid,
+    COALESCE(rawdata ->> 'title', '') AS title,
+    COALESCE(rawdata ->> 'content', '') AS content,
+    COALESCE(rawdata ->> 'format', 'text') AS format,
+    COALESCE(rawdata ->> 'resourceurl', '') AS resourceurl,
+    COALESCE(rawdata -> 'attachments', '[]'::jsonb) AS attachments
+
+FROM lessons;
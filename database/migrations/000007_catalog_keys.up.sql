@@ -0,0 +1,47 @@
+-- natural keys must be unique before they are indexed, duplicates are listed to be fixed by hand
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(format('%s %s: %s', kind, key, ids), '; ') INTO duplicates
+    FROM (
+        SELECT 'course' AS kind, rawdata ->> 'courseid' AS key, string_agg(id::text, ', ' ORDER BY id) AS ids
+        FROM courses
+        WHERE rawdata ->> 'courseid' IS NOT NULL
+        GROUP BY 2
+        HAVING count(*) > 1
+        UNION ALL
+        SELECT 'lesson', rawdata ->> 'lessonid', string_agg(id::text, ', ' ORDER BY id)
+        FROM lessons
+        WHERE rawdata ->> 'lessonid' IS NOT NULL
+        GROUP BY 2
+        HAVING count(*) > 1
+    ) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'duplicate catalog keys, give each row its own key or remove it: %', duplicates;
+    END IF;
+END;
+$$;
+
+-- natural keys used by catalog import
+CREATE UNIQUE INDEX IF NOT EXISTS courses_courseid_idx ON courses ((rawdata ->> 'courseid'));
+CREATE UNIQUE INDEX IF NOT EXISTS lessons_lessonid_idx ON lessons ((rawdata ->> 'lessonid'));
+CREATE INDEX IF NOT EXISTS lessons_courseid_idx ON lessons ((rawdata ->> 'courseid'));
+
+DROP VIEW IF EXISTS lessons_v;
+
+-- lessons belong to a course by its courseid and are ordered by position
+CREATE VIEW lessons_v AS
+SELECT
+    id, created,
+    COALESCE(rawdata ->> 'lessonid', '') AS lessonid,
+    COALESCE(rawdata ->> 'courseid', '') AS courseid,
+    COALESCE((rawdata ->> 'position')::int, 0) AS position,
+    COALESCE(rawdata ->> 'title', '') AS title,
+    COALESCE(rawdata ->> 'content', '') AS content,
+    COALESCE(rawdata ->> 'format', 'text') AS format,
+    COALESCE(rawdata ->> 'resourceurl', '') AS resourceurl,
+    COALESCE(rawdata -> 'attachments', '[]'::jsonb) AS attachments
+
+FROM lessons;
@@ -0,0 +1,10 @@
+-- token buckets of rate limits shared by all instances
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key     TEXT PRIMARY KEY,
+    tokens  DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL DEFAULT true,
+    updated TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- unused buckets are deleted by age
+CREATE INDEX IF NOT EXISTS rate_limits_updated_idx ON rate_limits (updated);
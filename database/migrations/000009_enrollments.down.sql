@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS enrollments_v;
+DROP TABLE IF EXISTS enrollments;
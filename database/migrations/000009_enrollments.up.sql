@@ -0,0 +1,20 @@
+-- users enrolled in courses, with their progress
+CREATE TABLE IF NOT EXISTS enrollments (
+    id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE DEFAULT NOW (),
+    userid UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    courseid UUID NOT NULL REFERENCES courses (id) ON DELETE CASCADE,
+    rawdata JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS enrollments_user_course_idx ON enrollments (userid, courseid);
+CREATE INDEX IF NOT EXISTS enrollments_courseid_idx ON enrollments (courseid);
+
+-- define views that extract from json
+CREATE VIEW enrollments_v AS
+SELECT
+    id, created, userid, courseid,
+    COALESCE((rawdata ->> 'progress')::int, 0) AS progress,
+    (rawdata ->> 'completed')::timestamptz AS completed
+
+FROM enrollments;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS lessons_notify_update ON lessons;
+DROP TRIGGER IF EXISTS lessons_notify_change ON lessons;
+DROP TRIGGER IF EXISTS courses_notify_update ON courses;
+DROP TRIGGER IF EXISTS courses_notify_change ON courses;
+DROP FUNCTION IF EXISTS notify_change ();
+DROP TABLE IF EXISTS change_events;
@@ -0,0 +1,48 @@
+-- changes of courses and lessons, streamed by GET /api/v1/events
+CREATE TABLE IF NOT EXISTS change_events (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    type TEXT NOT NULL,
+    action TEXT NOT NULL,
+    resourceid UUID NOT NULL
+);
+
+-- old events are deleted by age
+CREATE INDEX IF NOT EXISTS change_events_created_idx ON change_events (created);
+
+-- record the change of a row and notify listeners of the change_events channel,
+-- the argument of the trigger is the resource type
+CREATE OR REPLACE FUNCTION notify_change () RETURNS TRIGGER AS $$
+DECLARE
+    event change_events;
+BEGIN
+    INSERT INTO change_events (type, action, resourceid)
+    VALUES (
+        TG_ARGV[0],
+        CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        CASE TG_OP WHEN 'DELETE' THEN OLD.id ELSE NEW.id END
+    )
+    RETURNING * INTO event;
+
+    PERFORM pg_notify('change_events', json_build_object(
+        'id', event.id,
+        'created', event.created,
+        'type', event.type,
+        'action', event.action,
+        'resourceId', event.resourceid
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- updates which change nothing, e.g. of a repeated catalog import, are not events
+CREATE TRIGGER courses_notify_change AFTER INSERT OR DELETE ON courses
+FOR EACH ROW EXECUTE FUNCTION notify_change ('course');
+CREATE TRIGGER courses_notify_update AFTER UPDATE ON courses
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION notify_change ('course');
+
+CREATE TRIGGER lessons_notify_change AFTER INSERT OR DELETE ON lessons
+FOR EACH ROW EXECUTE FUNCTION notify_change ('lesson');
+CREATE TRIGGER lessons_notify_update AFTER UPDATE ON lessons
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION notify_change ('lesson');
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS enrollments_webhooks ON enrollments;
+DROP TRIGGER IF EXISTS courses_webhooks ON courses;
+DROP FUNCTION IF EXISTS webhooks_enrollment_completed ();
+DROP FUNCTION IF EXISTS webhooks_course_published ();
+DROP FUNCTION IF EXISTS enqueue_webhooks (TEXT, JSONB);
+DROP TABLE IF EXISTS webhook_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
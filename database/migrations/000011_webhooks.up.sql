@@ -0,0 +1,90 @@
+-- subscriptions of partner systems to events, deliveries are signed with the secret
+CREATE TABLE IF NOT EXISTS webhooks (
+    id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    url TEXT NOT NULL,
+    events JSONB NOT NULL DEFAULT '[]',
+    secret TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- one event sent to one webhook, retried until it is delivered or failed
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID DEFAULT uuid_generate_v4 () PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    webhookid UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    eventid UUID NOT NULL,
+    event TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    redelivery BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    nextattempt TIMESTAMP WITH TIME ZONE DEFAULT NOW (),
+    responsestatus INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhookid_idx ON webhook_deliveries (webhookid, created);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (nextattempt) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_created_idx ON webhook_deliveries (created);
+
+-- every request sent for a delivery, with the response of the receiver
+CREATE TABLE IF NOT EXISTS webhook_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    deliveryid UUID NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    responsestatus INT NOT NULL DEFAULT 0,
+    responsebody TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    durationms BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS webhook_attempts_deliveryid_idx ON webhook_attempts (deliveryid, id);
+
+-- queue a delivery of the event to every active webhook subscribed to its type,
+-- in the transaction of the change, so no event is lost or sent for a rolled back change
+CREATE OR REPLACE FUNCTION enqueue_webhooks (event_type TEXT, data JSONB) RETURNS VOID AS $$
+DECLARE
+    event_id UUID := uuid_generate_v4 ();
+BEGIN
+    INSERT INTO webhook_deliveries (webhookid, eventid, event, payload)
+    SELECT id, event_id, event_type, jsonb_build_object(
+        'id', event_id,
+        'type', event_type,
+        'created', NOW (),
+        'data', data
+    )
+    FROM webhooks
+    WHERE active AND events ? event_type;
+END;
+$$ LANGUAGE plpgsql;
+
+-- a course is published, when published is set for the first time
+CREATE OR REPLACE FUNCTION webhooks_course_published () RETURNS TRIGGER AS $$
+BEGIN
+    IF btrim(COALESCE(NEW.rawdata ->> 'published', '')) <> ''
+        AND (TG_OP = 'INSERT' OR btrim(COALESCE(OLD.rawdata ->> 'published', '')) = '') THEN
+        PERFORM enqueue_webhooks ('course.published', (SELECT to_jsonb (v) FROM courses_v v WHERE v.id = NEW.id));
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- an enrollment is completed, when completed is set for the first time
+CREATE OR REPLACE FUNCTION webhooks_enrollment_completed () RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.rawdata ->> 'completed' IS NOT NULL
+        AND (TG_OP = 'INSERT' OR OLD.rawdata ->> 'completed' IS NULL) THEN
+        PERFORM enqueue_webhooks ('enrollment.completed', (SELECT to_jsonb (v) FROM enrollments_v v WHERE v.id = NEW.id));
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER courses_webhooks AFTER INSERT OR UPDATE ON courses
+FOR EACH ROW EXECUTE FUNCTION webhooks_course_published ();
+
+CREATE TRIGGER enrollments_webhooks AFTER INSERT OR UPDATE ON enrollments
+FOR EACH ROW EXECUTE FUNCTION webhooks_enrollment_completed ();
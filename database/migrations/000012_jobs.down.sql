@@ -0,0 +1,18 @@
+-- webhook deliveries are claimed from their table again
+CREATE OR REPLACE FUNCTION enqueue_webhooks (event_type TEXT, data JSONB) RETURNS VOID AS $$
+DECLARE
+    event_id UUID := uuid_generate_v4 ();
+BEGIN
+    INSERT INTO webhook_deliveries (webhookid, eventid, event, payload)
+    SELECT id, event_id, event_type, jsonb_build_object(
+        'id', event_id,
+        'type', event_type,
+        'created', NOW (),
+        'data', data
+    )
+    FROM webhooks
+    WHERE active AND events ? event_type;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS jobs;
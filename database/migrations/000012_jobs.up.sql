@@ -0,0 +1,54 @@
+-- background jobs, claimed by workers with FOR UPDATE SKIP LOCKED
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    kind TEXT NOT NULL,
+    args JSONB NOT NULL DEFAULT '{}',
+    uniquekey TEXT,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    runat TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    lockeduntil TIMESTAMP WITH TIME ZONE,
+    lockedby TEXT,
+    lasterror TEXT NOT NULL DEFAULT '',
+    finished TIMESTAMP WITH TIME ZONE
+);
+
+-- due jobs, and running jobs of workers which stopped before their lease ended
+CREATE INDEX IF NOT EXISTS jobs_pending_idx ON jobs (runat, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS jobs_running_idx ON jobs (lockeduntil) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS jobs_finished_idx ON jobs (finished) WHERE status = 'done';
+CREATE INDEX IF NOT EXISTS jobs_dead_idx ON jobs (kind, finished) WHERE status = 'dead';
+
+-- one queued job per kind and unique key, done and dead jobs do not count
+CREATE UNIQUE INDEX IF NOT EXISTS jobs_uniquekey_idx ON jobs (kind, uniquekey)
+WHERE uniquekey IS NOT NULL AND status IN ('pending', 'running');
+
+-- webhook deliveries are attempted by webhook.deliver jobs, queued in the same transaction
+CREATE OR REPLACE FUNCTION enqueue_webhooks (event_type TEXT, data JSONB) RETURNS VOID AS $$
+DECLARE
+    event_id UUID := uuid_generate_v4 ();
+BEGIN
+    WITH deliveries AS (
+        INSERT INTO webhook_deliveries (webhookid, eventid, event, payload)
+        SELECT id, event_id, event_type, jsonb_build_object(
+            'id', event_id,
+            'type', event_type,
+            'created', NOW (),
+            'data', data
+        )
+        FROM webhooks
+        WHERE active AND events ? event_type
+        RETURNING id
+    )
+    INSERT INTO jobs (kind, args, uniquekey)
+    SELECT 'webhook.deliver', jsonb_build_object('deliveryId', id), id::text
+    FROM deliveries;
+END;
+$$ LANGUAGE plpgsql;
+
+-- deliveries queued before jobs existed
+INSERT INTO jobs (kind, args, uniquekey, runat)
+SELECT 'webhook.deliver', jsonb_build_object('deliveryId', id), id::text, COALESCE(nextattempt, NOW ())
+FROM webhook_deliveries
+WHERE status = 'pending';
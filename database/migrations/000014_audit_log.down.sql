@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS webhooks_audit_update ON webhooks;
+DROP TRIGGER IF EXISTS webhooks_audit ON webhooks;
+DROP TRIGGER IF EXISTS users_audit_update ON users;
+DROP TRIGGER IF EXISTS users_audit ON users;
+DROP TRIGGER IF EXISTS enrollments_audit_update ON enrollments;
+DROP TRIGGER IF EXISTS enrollments_audit ON enrollments;
+DROP TRIGGER IF EXISTS lessons_audit_update ON lessons;
+DROP TRIGGER IF EXISTS lessons_audit ON lessons;
+DROP TRIGGER IF EXISTS courses_audit_update ON courses;
+DROP TRIGGER IF EXISTS courses_audit ON courses;
+DROP FUNCTION IF EXISTS audit_change ();
+DROP FUNCTION IF EXISTS audit_append (TEXT, TEXT, TEXT, TEXT, JSONB, JSONB, TEXT, TEXT);
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS audit_log_append_only ();
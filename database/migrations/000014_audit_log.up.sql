@@ -0,0 +1,167 @@
+-- append-only log of changes and auth events, with an optional hash chain
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW (),
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    resourcetype TEXT NOT NULL,
+    resourceid TEXT NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    requestid TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    prevhash TEXT,
+    hash TEXT
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (actor, id);
+CREATE INDEX IF NOT EXISTS audit_log_resource_idx ON audit_log (resourcetype, resourceid, id);
+CREATE INDEX IF NOT EXISTS audit_log_created_idx ON audit_log (created);
+CREATE INDEX IF NOT EXISTS audit_log_chain_idx ON audit_log (id) WHERE hash IS NOT NULL;
+
+-- entries are never changed or removed
+CREATE OR REPLACE FUNCTION audit_log_append_only () RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION audit_log_append_only ();
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only ();
+
+-- append an entry, chained to the last chained one if opendavinci.audit_chain is on,
+-- see AUDIT_HASH_CHAIN. The hash is SHA-256 of the fields joined by newlines, see audit.Hash.
+CREATE OR REPLACE FUNCTION audit_append (
+    actor_id TEXT, action_name TEXT, resource_type TEXT, resource_id TEXT,
+    old_values JSONB, new_values JSONB, request_id TEXT, client_ip TEXT
+) RETURNS VOID AS $$
+DECLARE
+    entry audit_log;
+    chained BOOLEAN := COALESCE(current_setting('opendavinci.audit_chain', true), '') = 'on';
+BEGIN
+    IF chained THEN
+        -- one chained entry at a time, until commit, so the chain follows the order of IDs
+        PERFORM pg_advisory_xact_lock(hashtext('audit_log'));
+        entry.prevhash := COALESCE(
+            (SELECT a.hash FROM audit_log a WHERE a.hash IS NOT NULL ORDER BY a.id DESC LIMIT 1), ''
+        );
+    END IF;
+
+    entry.id := nextval(pg_get_serial_sequence('audit_log', 'id'));
+    entry.created := NOW ();
+    entry.actor := actor_id;
+    entry.action := action_name;
+    entry.resourcetype := resource_type;
+    entry.resourceid := COALESCE(resource_id, '');
+    -- JSON null is stored as NULL, so entries read with null are hashed like ones without values
+    entry.before := NULLIF(old_values, 'null'::jsonb);
+    entry.after := NULLIF(new_values, 'null'::jsonb);
+    entry.requestid := COALESCE(request_id, '');
+    entry.ip := COALESCE(client_ip, '');
+
+    IF chained THEN
+        entry.hash := encode(sha256(convert_to(concat_ws(E'\n',
+            entry.prevhash,
+            entry.id::text,
+            to_char(entry.created AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'),
+            entry.actor,
+            entry.action,
+            entry.resourcetype,
+            entry.resourceid,
+            COALESCE(entry.before::text, ''),
+            COALESCE(entry.after::text, ''),
+            entry.requestid,
+            entry.ip
+        ), 'UTF8')), 'hex');
+    END IF;
+
+    INSERT INTO audit_log SELECT entry.*;
+END;
+$$ LANGUAGE plpgsql;
+
+-- record the change of a row with its actor, the arguments of the trigger are the resource type
+-- and the fields to redact. Fields of rawdata are compared like columns.
+CREATE OR REPLACE FUNCTION audit_change () RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    old_values JSONB;
+    new_values JSONB;
+    field TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+        IF jsonb_typeof(old_row -> 'rawdata') = 'object' THEN
+            old_row := (old_row - 'rawdata') || (old_row -> 'rawdata');
+        END IF;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+        IF jsonb_typeof(new_row -> 'rawdata') = 'object' THEN
+            new_row := (new_row - 'rawdata') || (new_row -> 'rawdata');
+        END IF;
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        -- only the changed fields
+        SELECT jsonb_object_agg(o.key, o.value) INTO old_values
+        FROM jsonb_each(old_row) o WHERE o.value IS DISTINCT FROM new_row -> o.key;
+        SELECT jsonb_object_agg(n.key, n.value) INTO new_values
+        FROM jsonb_each(new_row) n WHERE n.value IS DISTINCT FROM old_row -> n.key;
+    ELSE
+        old_values := old_row;
+        new_values := new_row;
+    END IF;
+
+    -- changes of secrets are recorded, their values are not
+    FOREACH field IN ARRAY TG_ARGV[1:] LOOP
+        IF old_values ? field THEN
+            old_values := jsonb_set(old_values, ARRAY[field], '"[redacted]"');
+        END IF;
+        IF new_values ? field THEN
+            new_values := jsonb_set(new_values, ARRAY[field], '"[redacted]"');
+        END IF;
+    END LOOP;
+
+    PERFORM audit_append(
+        COALESCE(NULLIF(current_setting('opendavinci.actor', true), ''), 'system'),
+        TG_ARGV[0] || '.' || CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        TG_ARGV[0],
+        COALESCE(new_row, old_row) ->> 'id',
+        old_values,
+        new_values,
+        current_setting('opendavinci.request_id', true),
+        current_setting('opendavinci.ip', true)
+    );
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- updates which change nothing, e.g. of a repeated catalog import, are not recorded
+CREATE TRIGGER courses_audit AFTER INSERT OR DELETE ON courses
+FOR EACH ROW EXECUTE FUNCTION audit_change ('course');
+CREATE TRIGGER courses_audit_update AFTER UPDATE ON courses
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('course');
+
+CREATE TRIGGER lessons_audit AFTER INSERT OR DELETE ON lessons
+FOR EACH ROW EXECUTE FUNCTION audit_change ('lesson');
+CREATE TRIGGER lessons_audit_update AFTER UPDATE ON lessons
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('lesson');
+
+CREATE TRIGGER enrollments_audit AFTER INSERT OR DELETE ON enrollments
+FOR EACH ROW EXECUTE FUNCTION audit_change ('enrollment');
+CREATE TRIGGER enrollments_audit_update AFTER UPDATE ON enrollments
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('enrollment');
+
+CREATE TRIGGER users_audit AFTER INSERT OR DELETE ON users
+FOR EACH ROW EXECUTE FUNCTION audit_change ('user', 'password');
+CREATE TRIGGER users_audit_update AFTER UPDATE ON users
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('user', 'password');
+
+CREATE TRIGGER webhooks_audit AFTER INSERT OR DELETE ON webhooks
+FOR EACH ROW EXECUTE FUNCTION audit_change ('webhook', 'secret');
+CREATE TRIGGER webhooks_audit_update AFTER UPDATE ON webhooks
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('webhook', 'secret');
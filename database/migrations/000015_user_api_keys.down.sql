@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS users_audit ON users;
+DROP TRIGGER IF EXISTS users_audit_update ON users;
+CREATE TRIGGER users_audit AFTER INSERT OR DELETE ON users
+FOR EACH ROW EXECUTE FUNCTION audit_change ('user', 'password');
+CREATE TRIGGER users_audit_update AFTER UPDATE ON users
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('user', 'password');
+
+DROP INDEX IF EXISTS users_apikeyhash_idx;
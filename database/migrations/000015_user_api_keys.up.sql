@@ -0,0 +1,13 @@
+-- API keys of users, stored as the hex SHA-256 of the key in rawdata.apikeyhash, e.g.
+-- UPDATE users SET rawdata = rawdata || jsonb_build_object('apikeyhash', encode(sha256('<key>'), 'hex')).
+-- GET /api/v1/token/new with the key in X-API-Key issues a token of the user with its role.
+CREATE UNIQUE INDEX IF NOT EXISTS users_apikeyhash_idx ON users ((rawdata ->> 'apikeyhash'))
+WHERE rawdata ? 'apikeyhash';
+
+-- changes of API keys are recorded, their hashes are not
+DROP TRIGGER IF EXISTS users_audit ON users;
+DROP TRIGGER IF EXISTS users_audit_update ON users;
+CREATE TRIGGER users_audit AFTER INSERT OR DELETE ON users
+FOR EACH ROW EXECUTE FUNCTION audit_change ('user', 'password', 'apikeyhash');
+CREATE TRIGGER users_audit_update AFTER UPDATE ON users
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION audit_change ('user', 'password', 'apikeyhash');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS change_events_txid_idx;
+
+ALTER TABLE change_events DROP COLUMN IF EXISTS txid;
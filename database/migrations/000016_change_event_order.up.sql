@@ -0,0 +1,5 @@
+-- transaction of each change event, IDs are taken before commit, so a later ID can commit first;
+-- the feed reads events of finished transactions only, ordered by transaction and ID
+ALTER TABLE change_events ADD COLUMN IF NOT EXISTS txid xid8 NOT NULL DEFAULT pg_current_xact_id ();
+
+CREATE INDEX IF NOT EXISTS change_events_txid_idx ON change_events (txid, id);
@@ -1,24 +1,134 @@
 package database
 
-import "opendavinci/queries"
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+
+	"opendavinci/metrics"
+	"opendavinci/queries"
+)
+
+var (
+	poolMu sync.Mutex
+	pool   *sqlx.DB
+)
 
 // Queries struct for collect all app queries.
 type Queries struct {
-	*queries.CourseQueries // load queries from Course model
+	*queries.CourseQueries      // load queries from Course model
+	*queries.LessonQueries      // load queries from Lesson model
+	*queries.CatalogQueries     // load queries for catalog import and export
+	*queries.RateLimitQueries   // load queries for rate limit buckets
+	*queries.UserQueries        // load queries from User model
+	*queries.EnrollmentQueries  // load queries from Enrollment model
+	*queries.EventQueries       // load queries from Event model
+	*queries.WebhookQueries     // load queries from Webhook model
+	*queries.JobQueries         // load queries from Job model
+	*queries.IdempotencyQueries // load queries from IdempotencyKey model
+	*queries.AuditQueries       // load queries from AuditEntry model
 }
 
 // OpenDBConnection is our step to switch between diff database (pg/sqlite).
-func OpenDBConnection() (*Queries, error) {
-	// Define a new PostgreSQL connection.
-	db, err := PostgreSQLConnection()
+// The connection pool is created once and shared by all requests,
+// queries run with ctx, e.g. c.UserContext() to trace them within the request.
+func OpenDBConnection(ctx context.Context) (*Queries, error) {
+	conn, err := openPool()
 	if err != nil {
 		return nil, err
 	}
 
+	return newQueries(queries.NewDB(ctx, conn)), nil
+}
+
+// InTx method for running fn with queries in one transaction, it is committed if fn returns nil,
+// e.g. to enqueue jobs together with the change they are for.
+func (q *Queries) InTx(fn func(tx *Queries) error) error {
+	return q.CourseQueries.InTx(func(db *queries.DB) error {
+		return fn(newQueries(db))
+	})
+}
+
+// newQueries func for binding the queries of all models to db.
+func newQueries(db *queries.DB) *Queries {
 	return &Queries{
 		// Set queries from models:
-		CourseQueries: &queries.CourseQueries{DB: db}, // from Course model
-	}, nil
+		CourseQueries:      &queries.CourseQueries{DB: db},      // from Course model
+		LessonQueries:      &queries.LessonQueries{DB: db},      // from Lesson model
+		CatalogQueries:     &queries.CatalogQueries{DB: db},     // for catalog import and export
+		RateLimitQueries:   &queries.RateLimitQueries{DB: db},   // for rate limit buckets
+		UserQueries:        &queries.UserQueries{DB: db},        // from User model
+		EnrollmentQueries:  &queries.EnrollmentQueries{DB: db},  // from Enrollment model
+		EventQueries:       &queries.EventQueries{DB: db},       // from Event model
+		WebhookQueries:     &queries.WebhookQueries{DB: db},     // from Webhook model
+		JobQueries:         &queries.JobQueries{DB: db},         // from Job model
+		IdempotencyQueries: &queries.IdempotencyQueries{DB: db}, // from IdempotencyKey model
+		AuditQueries:       &queries.AuditQueries{DB: db},       // from AuditEntry model
+	}
+}
+
+// CloseDBConnection func for closing the shared connection pool on shutdown.
+// It waits for queries in progress, the next OpenDBConnection opens a new pool.
+func CloseDBConnection() error {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+
+	if pool == nil {
+		return nil
+	}
+
+	err := pool.Close()
+	pool = nil
+
+	return err
+}
+
+func init() {
+	metrics.RegisterDBStats(Stats)
+	metrics.RegisterEnrollments(countEnrollments)
+}
+
+// countEnrollments func for getting the number of all and of completed enrollments.
+func countEnrollments(ctx context.Context) (int64, int64, error) {
+	db, err := OpenDBConnection(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	counts, err := db.CountEnrollments()
+
+	return counts.Total, counts.Completed, err
+}
+
+// Stats func for getting statistics of the shared connection pool.
+func Stats() sql.DBStats {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+
+	if pool == nil {
+		return sql.DBStats{}
+	}
+
+	return pool.Stats()
+}
+
+func openPool() (*sqlx.DB, error) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+
+	if pool != nil {
+		return pool, nil
+	}
+
+	// Define a new PostgreSQL connection.
+	db, err := PostgreSQLConnection()
+	if err != nil {
+		return nil, err
+	}
+	pool = db
+
+	return pool, nil
 }
 
 /**************************************
@@ -2,32 +2,34 @@ package database
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"opendavinci/config"
 )
 
 // PostgreSQLConnection func for connection to PostgreSQL database.
 func PostgreSQLConnection() (*sqlx.DB, error) {
 	// Define database connection settings.
-	maxConn, _ := strconv.Atoi(os.Getenv("DB_MAX_CONNECTIONS"))
-	maxIdleConn, _ := strconv.Atoi(os.Getenv("DB_MAX_IDLE_CONNECTIONS"))
-	maxLifetimeConn, _ := strconv.Atoi(os.Getenv("DB_MAX_LIFETIME_CONNECTIONS"))
+	cfg := config.Get().DB
 
 	// Define database connection for PostgreSQL.
-	db, err := sqlx.Connect("pgx", os.Getenv("DB_SERVER_URL"))
+	cc, err := pgx.ParseConfig(cfg.ServerURL)
 	if err != nil {
 		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
+	if config.Get().Audit.HashChain {
+		// Read by audit_append of every session.
+		cc.RuntimeParams["opendavinci.audit_chain"] = "on"
+	}
+	db := sqlx.NewDb(stdlib.OpenDB(*cc), "pgx")
 
 	// Set database connection settings.
-	db.SetMaxOpenConns(maxConn)                           // the default is 0 (unlimited)
-	db.SetMaxIdleConns(maxIdleConn)                       // defaultMaxIdleConns = 2
-	db.SetConnMaxLifetime(time.Duration(maxLifetimeConn)) // 0, connections are reused forever
+	db.SetMaxOpenConns(cfg.MaxConnections)     // the default is 0 (unlimited)
+	db.SetMaxIdleConns(cfg.MaxIdleConnections) // defaultMaxIdleConns = 2
+	db.SetConnMaxLifetime(cfg.MaxLifetime)     // 0, connections are reused forever
 
 	// Try to ping database.
 	if err := db.Ping(); err != nil {
@@ -2,21 +2,18 @@ package database
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"time"
 
 	"github.com/jmoiron/sqlx"
 
+	"opendavinci/config"
+
 	_ "github.com/mattn/go-sqlite3" // load  driver for SQLite
 )
 
 // SqliteConnection func for connection to SQLite database.
 func SqliteConnection() (*sqlx.DB, error) {
 	// Define database connection settings.
-	maxConn, _ := strconv.Atoi(os.Getenv("DB_MAX_CONNECTIONS"))
-	maxIdleConn, _ := strconv.Atoi(os.Getenv("DB_MAX_IDLE_CONNECTIONS"))
-	maxLifetimeConn, _ := strconv.Atoi(os.Getenv("DB_MAX_LIFETIME_CONNECTIONS"))
+	cfg := config.Get().DB
 
 	// Define database connection for SQLite.
 	db, err := sqlx.Connect("sqlite3", ":memory:")
@@ -25,9 +22,9 @@ func SqliteConnection() (*sqlx.DB, error) {
 	}
 
 	// Set database connection settings.
-	db.SetMaxOpenConns(maxConn)                           // the default is 0 (unlimited)
-	db.SetMaxIdleConns(maxIdleConn)                       // defaultMaxIdleConns = 2
-	db.SetConnMaxLifetime(time.Duration(maxLifetimeConn)) // 0, connections are reused forever
+	db.SetMaxOpenConns(cfg.MaxConnections)     // the default is 0 (unlimited)
+	db.SetMaxIdleConns(cfg.MaxIdleConnections) // defaultMaxIdleConns = 2
+	db.SetConnMaxLifetime(cfg.MaxLifetime)     // 0, connections are reused forever
 
 	// Try to ping database.
 	if err := db.Ping(); err != nil {
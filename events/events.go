@@ -0,0 +1,278 @@
+// Package events fans out changes of courses and lessons to the streams of the change feed.
+// Triggers record changes in the change_events table and notify the change_events channel.
+// A source of each instance listens to the channel, or polls the table where LISTEN is not available.
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/metrics"
+	"opendavinci/models"
+)
+
+// Types are the resource types of events.
+var Types = []string{"course", "lesson"}
+
+// Errors of Subscribe.
+var (
+	ErrStopped            = errors.New("change feed is stopped")
+	ErrTooManySubscribers = errors.New("too many change feed subscribers")
+)
+
+// buffer is how many events a subscriber may lag behind, slower ones are dropped
+// and resume from the table with the ID of the last event they got.
+const buffer = 64
+
+// Broker struct to describe the subscribers of one instance and the source of their events.
+type Broker struct {
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	max     int
+	stopped bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Subscription struct to describe a subscriber of events of some types.
+type Subscription struct {
+	broker *Broker
+	types  []string // empty is all types
+	ch     chan models.Event
+	lagged bool
+}
+
+var (
+	brokerMu sync.Mutex
+	broker   *Broker
+)
+
+// Subscribe func for getting events of types, or of all types if none is given.
+// The source of events starts with the first subscriber of the instance.
+func Subscribe(types []string) (*Subscription, error) {
+	b := openBroker()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return nil, ErrStopped
+	}
+	if len(b.subs) >= b.max {
+		return nil, ErrTooManySubscribers
+	}
+
+	s := &Subscription{broker: b, types: types, ch: make(chan models.Event, buffer)}
+	b.subs[s] = struct{}{}
+	metrics.EventSubscribers.Inc()
+
+	return s, nil
+}
+
+// Stop func for ending all subscriptions and the source, e.g. on shutdown,
+// so streams do not hold the server until the deadline.
+func Stop() {
+	brokerMu.Lock()
+	b := broker
+	brokerMu.Unlock()
+
+	if b != nil {
+		b.stop()
+	}
+}
+
+// openBroker func for creating the broker once and starting its source.
+func openBroker() *Broker {
+	brokerMu.Lock()
+	defer brokerMu.Unlock()
+
+	if broker != nil {
+		return broker
+	}
+
+	cfg := config.Get().Events
+
+	ctx, cancel := context.WithCancel(context.Background())
+	broker = &Broker{
+		subs:   map[*Subscription]struct{}{},
+		max:    cfg.MaxSubscribers,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go broker.run(ctx, newSource(cfg), cfg.Retention)
+
+	return broker
+}
+
+// newSource func for choosing the source of events by EVENTS_SOURCE.
+func newSource(cfg config.Events) source {
+	if cfg.Source == "poll" {
+		return poll(cfg.PollInterval)
+	}
+
+	return listen(cfg.PollInterval)
+}
+
+// Events method for receiving events, the channel is closed when the subscription ends.
+func (s *Subscription) Events() <-chan models.Event {
+	return s.ch
+}
+
+// Lagged method for checking, if the subscription ended because events were not received fast enough.
+func (s *Subscription) Lagged() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	return s.lagged
+}
+
+// Close method for ending the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.remove(s)
+}
+
+// publish method for sending e to the subscribers of its type.
+func (b *Broker) publish(e models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		if len(s.types) > 0 && !slices.Contains(s.types, e.Type) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			s.lagged = true
+			b.remove(s)
+			metrics.EventsDropped.Inc()
+		}
+	}
+}
+
+// remove method for ending subscription s, the lock is held.
+func (b *Broker) remove(s *Subscription) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+	delete(b.subs, s)
+	close(s.ch)
+	metrics.EventSubscribers.Dec()
+}
+
+// stop method for ending all subscriptions and waiting for the source.
+func (b *Broker) stop() {
+	b.mu.Lock()
+	b.stopped = true
+	for s := range b.subs {
+		b.remove(s)
+	}
+	b.mu.Unlock()
+
+	b.cancel()
+	<-b.done
+}
+
+// retryAfter is how long the source waits after it failed, doubled up to maxRetryAfter.
+const (
+	retryAfter    = time.Second
+	maxRetryAfter = 30 * time.Second
+)
+
+// run method for reading events of the source until ctx is done.
+// Events older than retention are deleted on the way, by every instance.
+func (b *Broker) run(ctx context.Context, src source, retention time.Duration) {
+	defer close(b.done)
+
+	// Subscribers get events from now on, older ones are read from the table.
+	after, err := latestEventID(ctx)
+	for err != nil {
+		slog.Error("Change feed not started", "error", err)
+		if !sleep(ctx, maxRetryAfter) {
+			return
+		}
+		after, err = latestEventID(ctx)
+	}
+
+	publish := func(e models.Event) {
+		after = e.ID
+		b.publish(e)
+	}
+
+	go sweep(ctx, retention)
+
+	wait := retryAfter
+	for {
+		start := time.Now()
+		err := src(ctx, after, publish)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(start) > maxRetryAfter {
+			// The source worked for a while, the failure is a new one.
+			wait = retryAfter
+		}
+		slog.Warn("Change feed source failed", "error", err, "retryAfter", wait.String())
+		if !sleep(ctx, wait) {
+			return
+		}
+		wait = min(2*wait, maxRetryAfter)
+	}
+}
+
+// sweepInterval is how often old events are deleted.
+const sweepInterval = time.Hour
+
+// sweep func for deleting events older than retention until ctx is done.
+func sweep(ctx context.Context, retention time.Duration) {
+	t := time.NewTicker(sweepInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		db, err := database.OpenDBConnection(ctx)
+		if err == nil {
+			err = db.DeleteEvents(time.Now().Add(-retention))
+		}
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Old change events not deleted", "error", err)
+		}
+	}
+}
+
+// latestEventID func for getting the ID of the last event of the feed.
+func latestEventID(ctx context.Context) (int64, error) {
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return db.GetLatestEventID()
+}
+
+// sleep func for waiting d, false if ctx was done before.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/models"
+)
+
+// channel is the notification channel of the triggers of migration 000010.
+const channel = "change_events"
+
+// page is the number of events read from the table at once.
+const page = 100
+
+// source func type to describe a reader of the events after the event with ID after.
+// It calls publish in order until ctx is done, or returns the error it failed with.
+type source func(ctx context.Context, after int64, publish func(models.Event)) error
+
+// listen func for creating a source which reads new events from the table, whenever Postgres notifies
+// of one, with a connection outside the pool. Events are read from the table in order of the feed,
+// the ones of a transaction which ended before an older one are read every interval, once it ended too.
+func listen(interval time.Duration) source {
+	return func(ctx context.Context, after int64, publish func(models.Event)) error {
+		conn, err := pgx.Connect(ctx, config.Get().DB.ServerURL)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(context.Background())
+
+		if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+			return err
+		}
+
+		for {
+			if after, err = catchUp(ctx, after, publish); err != nil {
+				return err
+			}
+
+			// The connection stays open when the wait timed out.
+			wait, cancel := context.WithTimeout(ctx, interval)
+			_, err := conn.WaitForNotification(wait)
+			cancel()
+			if err != nil && (ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded)) {
+				return err
+			}
+		}
+	}
+}
+
+// poll func for creating a source which reads new events from the table every interval.
+func poll(interval time.Duration) source {
+	return func(ctx context.Context, after int64, publish func(models.Event)) error {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			var err error
+			if after, err = catchUp(ctx, after, publish); err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-t.C:
+			}
+		}
+	}
+}
+
+// catchUp func for publishing all events after the event with ID after,
+// it returns the ID of the last one.
+func catchUp(ctx context.Context, after int64, publish func(models.Event)) (int64, error) {
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return after, err
+	}
+
+	for {
+		events, err := db.GetEventsAfter(after, page)
+		if err != nil {
+			return after, err
+		}
+		for _, e := range events {
+			publish(e)
+			after = e.ID
+		}
+		if len(events) < page {
+			return after, nil
+		}
+	}
+}
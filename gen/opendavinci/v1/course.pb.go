@@ -0,0 +1,644 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: opendavinci/v1/course.proto
+
+package opendavinciv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Course is a course, fields are the ones of models.Course.
+type Course struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	CourseId    string                 `protobuf:"bytes,3,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Title       string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Instructor  string                 `protobuf:"bytes,5,opt,name=instructor,proto3" json:"instructor,omitempty"`
+	Description string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Subject     string                 `protobuf:"bytes,7,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Key of an uploaded image or URL of an external one.
+	Image string `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	// Signed URL of the image, set in responses.
+	ImageUrl string `protobuf:"bytes,9,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	// Paths of resized images by variant name, set in responses for uploaded images.
+	ImageVariants map[string]string `protobuf:"bytes,10,rep,name=image_variants,json=imageVariants,proto3" json:"image_variants,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Published     string            `protobuf:"bytes,11,opt,name=published,proto3" json:"published,omitempty"`
+	Updated       string            `protobuf:"bytes,12,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Course) Reset() {
+	*x = Course{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Course) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Course) ProtoMessage() {}
+
+func (x *Course) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Course.ProtoReflect.Descriptor instead.
+func (*Course) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Course) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Course) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Course) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *Course) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Course) GetInstructor() string {
+	if x != nil {
+		return x.Instructor
+	}
+	return ""
+}
+
+func (x *Course) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Course) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Course) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Course) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Course) GetImageVariants() map[string]string {
+	if x != nil {
+		return x.ImageVariants
+	}
+	return nil
+}
+
+func (x *Course) GetPublished() string {
+	if x != nil {
+		return x.Published
+	}
+	return ""
+}
+
+func (x *Course) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+type ListCoursesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Courses per page, 0 gets all courses.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoursesRequest) Reset() {
+	*x = ListCoursesRequest{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoursesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoursesRequest) ProtoMessage() {}
+
+func (x *ListCoursesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoursesRequest.ProtoReflect.Descriptor instead.
+func (*ListCoursesRequest) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCoursesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCoursesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListCoursesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Courses []*Course              `protobuf:"bytes,1,rep,name=courses,proto3" json:"courses,omitempty"`
+	// Cursor of the next page, empty on the last one.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoursesResponse) Reset() {
+	*x = ListCoursesResponse{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoursesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoursesResponse) ProtoMessage() {}
+
+func (x *ListCoursesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoursesResponse.ProtoReflect.Descriptor instead.
+func (*ListCoursesResponse) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCoursesResponse) GetCourses() []*Course {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+func (x *ListCoursesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourseRequest) Reset() {
+	*x = GetCourseRequest{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseRequest) ProtoMessage() {}
+
+func (x *GetCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseRequest.ProtoReflect.Descriptor instead.
+func (*GetCourseRequest) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCourseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCourseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Course        *Course                `protobuf:"bytes,1,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourseResponse) Reset() {
+	*x = GetCourseResponse{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseResponse) ProtoMessage() {}
+
+func (x *GetCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseResponse.ProtoReflect.Descriptor instead.
+func (*GetCourseResponse) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCourseResponse) GetCourse() *Course {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+type CreateCourseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID and created are set by the server.
+	Course        *Course `protobuf:"bytes,1,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourseRequest) Reset() {
+	*x = CreateCourseRequest{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourseRequest) ProtoMessage() {}
+
+func (x *CreateCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourseRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourseRequest) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCourseRequest) GetCourse() *Course {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+type CreateCourseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Course        *Course                `protobuf:"bytes,1,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourseResponse) Reset() {
+	*x = CreateCourseResponse{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourseResponse) ProtoMessage() {}
+
+func (x *CreateCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourseResponse.ProtoReflect.Descriptor instead.
+func (*CreateCourseResponse) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateCourseResponse) GetCourse() *Course {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+type UpdateCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Course        *Course                `protobuf:"bytes,1,opt,name=course,proto3" json:"course,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCourseRequest) Reset() {
+	*x = UpdateCourseRequest{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCourseRequest) ProtoMessage() {}
+
+func (x *UpdateCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCourseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCourseRequest) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateCourseRequest) GetCourse() *Course {
+	if x != nil {
+		return x.Course
+	}
+	return nil
+}
+
+type UpdateCourseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCourseResponse) Reset() {
+	*x = UpdateCourseResponse{}
+	mi := &file_opendavinci_v1_course_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCourseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCourseResponse) ProtoMessage() {}
+
+func (x *UpdateCourseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_course_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCourseResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCourseResponse) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_course_proto_rawDescGZIP(), []int{8}
+}
+
+var File_opendavinci_v1_course_proto protoreflect.FileDescriptor
+
+const file_opendavinci_v1_course_proto_rawDesc = "" +
+	"\n" +
+	"\x1bopendavinci/v1/course.proto\x12\x0eopendavinci.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdc\x03\n" +
+	"\x06Course\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\acreated\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x12\x1b\n" +
+	"\tcourse_id\x18\x03 \x01(\tR\bcourseId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x1e\n" +
+	"\n" +
+	"instructor\x18\x05 \x01(\tR\n" +
+	"instructor\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x18\n" +
+	"\asubject\x18\a \x01(\tR\asubject\x12\x14\n" +
+	"\x05image\x18\b \x01(\tR\x05image\x12\x1b\n" +
+	"\timage_url\x18\t \x01(\tR\bimageUrl\x12P\n" +
+	"\x0eimage_variants\x18\n" +
+	" \x03(\v2).opendavinci.v1.Course.ImageVariantsEntryR\rimageVariants\x12\x1c\n" +
+	"\tpublished\x18\v \x01(\tR\tpublished\x12\x18\n" +
+	"\aupdated\x18\f \x01(\tR\aupdated\x1a@\n" +
+	"\x12ImageVariantsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"B\n" +
+	"\x12ListCoursesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"h\n" +
+	"\x13ListCoursesResponse\x120\n" +
+	"\acourses\x18\x01 \x03(\v2\x16.opendavinci.v1.CourseR\acourses\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\"\n" +
+	"\x10GetCourseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x11GetCourseResponse\x12.\n" +
+	"\x06course\x18\x01 \x01(\v2\x16.opendavinci.v1.CourseR\x06course\"E\n" +
+	"\x13CreateCourseRequest\x12.\n" +
+	"\x06course\x18\x01 \x01(\v2\x16.opendavinci.v1.CourseR\x06course\"F\n" +
+	"\x14CreateCourseResponse\x12.\n" +
+	"\x06course\x18\x01 \x01(\v2\x16.opendavinci.v1.CourseR\x06course\"E\n" +
+	"\x13UpdateCourseRequest\x12.\n" +
+	"\x06course\x18\x01 \x01(\v2\x16.opendavinci.v1.CourseR\x06course\"\x16\n" +
+	"\x14UpdateCourseResponse2\xf9\x02\n" +
+	"\rCourseService\x12[\n" +
+	"\vListCourses\x12\".opendavinci.v1.ListCoursesRequest\x1a#.opendavinci.v1.ListCoursesResponse\"\x03\x90\x02\x01\x12U\n" +
+	"\tGetCourse\x12 .opendavinci.v1.GetCourseRequest\x1a!.opendavinci.v1.GetCourseResponse\"\x03\x90\x02\x01\x12Y\n" +
+	"\fCreateCourse\x12#.opendavinci.v1.CreateCourseRequest\x1a$.opendavinci.v1.CreateCourseResponse\x12Y\n" +
+	"\fUpdateCourse\x12#.opendavinci.v1.UpdateCourseRequest\x1a$.opendavinci.v1.UpdateCourseResponseB.Z,opendavinci/gen/opendavinci/v1;opendavinciv1b\x06proto3"
+
+var (
+	file_opendavinci_v1_course_proto_rawDescOnce sync.Once
+	file_opendavinci_v1_course_proto_rawDescData []byte
+)
+
+func file_opendavinci_v1_course_proto_rawDescGZIP() []byte {
+	file_opendavinci_v1_course_proto_rawDescOnce.Do(func() {
+		file_opendavinci_v1_course_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_opendavinci_v1_course_proto_rawDesc), len(file_opendavinci_v1_course_proto_rawDesc)))
+	})
+	return file_opendavinci_v1_course_proto_rawDescData
+}
+
+var file_opendavinci_v1_course_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_opendavinci_v1_course_proto_goTypes = []any{
+	(*Course)(nil),                // 0: opendavinci.v1.Course
+	(*ListCoursesRequest)(nil),    // 1: opendavinci.v1.ListCoursesRequest
+	(*ListCoursesResponse)(nil),   // 2: opendavinci.v1.ListCoursesResponse
+	(*GetCourseRequest)(nil),      // 3: opendavinci.v1.GetCourseRequest
+	(*GetCourseResponse)(nil),     // 4: opendavinci.v1.GetCourseResponse
+	(*CreateCourseRequest)(nil),   // 5: opendavinci.v1.CreateCourseRequest
+	(*CreateCourseResponse)(nil),  // 6: opendavinci.v1.CreateCourseResponse
+	(*UpdateCourseRequest)(nil),   // 7: opendavinci.v1.UpdateCourseRequest
+	(*UpdateCourseResponse)(nil),  // 8: opendavinci.v1.UpdateCourseResponse
+	nil,                           // 9: opendavinci.v1.Course.ImageVariantsEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_opendavinci_v1_course_proto_depIdxs = []int32{
+	10, // 0: opendavinci.v1.Course.created:type_name -> google.protobuf.Timestamp
+	9,  // 1: opendavinci.v1.Course.image_variants:type_name -> opendavinci.v1.Course.ImageVariantsEntry
+	0,  // 2: opendavinci.v1.ListCoursesResponse.courses:type_name -> opendavinci.v1.Course
+	0,  // 3: opendavinci.v1.GetCourseResponse.course:type_name -> opendavinci.v1.Course
+	0,  // 4: opendavinci.v1.CreateCourseRequest.course:type_name -> opendavinci.v1.Course
+	0,  // 5: opendavinci.v1.CreateCourseResponse.course:type_name -> opendavinci.v1.Course
+	0,  // 6: opendavinci.v1.UpdateCourseRequest.course:type_name -> opendavinci.v1.Course
+	1,  // 7: opendavinci.v1.CourseService.ListCourses:input_type -> opendavinci.v1.ListCoursesRequest
+	3,  // 8: opendavinci.v1.CourseService.GetCourse:input_type -> opendavinci.v1.GetCourseRequest
+	5,  // 9: opendavinci.v1.CourseService.CreateCourse:input_type -> opendavinci.v1.CreateCourseRequest
+	7,  // 10: opendavinci.v1.CourseService.UpdateCourse:input_type -> opendavinci.v1.UpdateCourseRequest
+	2,  // 11: opendavinci.v1.CourseService.ListCourses:output_type -> opendavinci.v1.ListCoursesResponse
+	4,  // 12: opendavinci.v1.CourseService.GetCourse:output_type -> opendavinci.v1.GetCourseResponse
+	6,  // 13: opendavinci.v1.CourseService.CreateCourse:output_type -> opendavinci.v1.CreateCourseResponse
+	8,  // 14: opendavinci.v1.CourseService.UpdateCourse:output_type -> opendavinci.v1.UpdateCourseResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_opendavinci_v1_course_proto_init() }
+func file_opendavinci_v1_course_proto_init() {
+	if File_opendavinci_v1_course_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_opendavinci_v1_course_proto_rawDesc), len(file_opendavinci_v1_course_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_opendavinci_v1_course_proto_goTypes,
+		DependencyIndexes: file_opendavinci_v1_course_proto_depIdxs,
+		MessageInfos:      file_opendavinci_v1_course_proto_msgTypes,
+	}.Build()
+	File_opendavinci_v1_course_proto = out.File
+	file_opendavinci_v1_course_proto_goTypes = nil
+	file_opendavinci_v1_course_proto_depIdxs = nil
+}
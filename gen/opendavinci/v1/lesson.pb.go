@@ -0,0 +1,658 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: opendavinci/v1/lesson.proto
+
+package opendavinciv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Lesson is a lesson of a course, fields are the ones of models.Lesson.
+type Lesson struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	LessonId string                 `protobuf:"bytes,3,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	CourseId string                 `protobuf:"bytes,4,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Position int32                  `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	Title    string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	// markdown, html or text.
+	Format        string        `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	ResourceUrl   string        `protobuf:"bytes,9,opt,name=resource_url,json=resourceUrl,proto3" json:"resource_url,omitempty"`
+	Attachments   []*Attachment `protobuf:"bytes,10,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lesson) Reset() {
+	*x = Lesson{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lesson) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lesson) ProtoMessage() {}
+
+func (x *Lesson) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lesson.ProtoReflect.Descriptor instead.
+func (*Lesson) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Lesson) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Lesson) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Lesson) GetLessonId() string {
+	if x != nil {
+		return x.LessonId
+	}
+	return ""
+}
+
+func (x *Lesson) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *Lesson) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Lesson) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Lesson) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Lesson) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Lesson) GetResourceUrl() string {
+	if x != nil {
+		return x.ResourceUrl
+	}
+	return ""
+}
+
+func (x *Lesson) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// Attachment is a file uploaded for a lesson.
+type Attachment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Key         string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Uploaded    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	// Signed download URL.
+	Url           string `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetUploaded() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Uploaded
+	}
+	return nil
+}
+
+func (x *Attachment) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Document is the rendered content of a lesson.
+type Document struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Revision string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Html     string                 `protobuf:"bytes,2,opt,name=html,proto3" json:"html,omitempty"`
+	// Table of contents.
+	Toc           []*Heading `protobuf:"bytes,3,rep,name=toc,proto3" json:"toc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Document) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *Document) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *Document) GetToc() []*Heading {
+	if x != nil {
+		return x.Toc
+	}
+	return nil
+}
+
+// Heading is an entry of the table of contents.
+type Heading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heading) Reset() {
+	*x = Heading{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heading) ProtoMessage() {}
+
+func (x *Heading) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heading.ProtoReflect.Descriptor instead.
+func (*Heading) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Heading) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Heading) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Heading) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type GetLessonRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLessonRequest) Reset() {
+	*x = GetLessonRequest{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLessonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLessonRequest) ProtoMessage() {}
+
+func (x *GetLessonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLessonRequest.ProtoReflect.Descriptor instead.
+func (*GetLessonRequest) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLessonRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetLessonResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lesson        *Lesson                `protobuf:"bytes,1,opt,name=lesson,proto3" json:"lesson,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLessonResponse) Reset() {
+	*x = GetLessonResponse{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLessonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLessonResponse) ProtoMessage() {}
+
+func (x *GetLessonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLessonResponse.ProtoReflect.Descriptor instead.
+func (*GetLessonResponse) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetLessonResponse) GetLesson() *Lesson {
+	if x != nil {
+		return x.Lesson
+	}
+	return nil
+}
+
+type GetLessonHTMLRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Revision of a cached document, like the ETag in If-None-Match.
+	Revision      string `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLessonHTMLRequest) Reset() {
+	*x = GetLessonHTMLRequest{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLessonHTMLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLessonHTMLRequest) ProtoMessage() {}
+
+func (x *GetLessonHTMLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLessonHTMLRequest.ProtoReflect.Descriptor instead.
+func (*GetLessonHTMLRequest) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetLessonHTMLRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetLessonHTMLRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type GetLessonHTMLResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty if not_modified.
+	Document *Document `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	// The content has the revision of the request.
+	NotModified   bool `protobuf:"varint,2,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLessonHTMLResponse) Reset() {
+	*x = GetLessonHTMLResponse{}
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLessonHTMLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLessonHTMLResponse) ProtoMessage() {}
+
+func (x *GetLessonHTMLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_opendavinci_v1_lesson_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLessonHTMLResponse.ProtoReflect.Descriptor instead.
+func (*GetLessonHTMLResponse) Descriptor() ([]byte, []int) {
+	return file_opendavinci_v1_lesson_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetLessonHTMLResponse) GetDocument() *Document {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *GetLessonHTMLResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+var File_opendavinci_v1_lesson_proto protoreflect.FileDescriptor
+
+const file_opendavinci_v1_lesson_proto_rawDesc = "" +
+	"\n" +
+	"\x1bopendavinci/v1/lesson.proto\x12\x0eopendavinci.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcd\x02\n" +
+	"\x06Lesson\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\acreated\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x12\x1b\n" +
+	"\tlesson_id\x18\x03 \x01(\tR\blessonId\x12\x1b\n" +
+	"\tcourse_id\x18\x04 \x01(\tR\bcourseId\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\a \x01(\tR\acontent\x12\x16\n" +
+	"\x06format\x18\b \x01(\tR\x06format\x12!\n" +
+	"\fresource_url\x18\t \x01(\tR\vresourceUrl\x12<\n" +
+	"\vattachments\x18\n" +
+	" \x03(\v2\x1a.opendavinci.v1.AttachmentR\vattachments\"\xb3\x01\n" +
+	"\n" +
+	"Attachment\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x126\n" +
+	"\buploaded\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\buploaded\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\"e\n" +
+	"\bDocument\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\x12\x12\n" +
+	"\x04html\x18\x02 \x01(\tR\x04html\x12)\n" +
+	"\x03toc\x18\x03 \x03(\v2\x17.opendavinci.v1.HeadingR\x03toc\"E\n" +
+	"\aHeading\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"\"\n" +
+	"\x10GetLessonRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x11GetLessonResponse\x12.\n" +
+	"\x06lesson\x18\x01 \x01(\v2\x16.opendavinci.v1.LessonR\x06lesson\"B\n" +
+	"\x14GetLessonHTMLRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\tR\brevision\"p\n" +
+	"\x15GetLessonHTMLResponse\x124\n" +
+	"\bdocument\x18\x01 \x01(\v2\x18.opendavinci.v1.DocumentR\bdocument\x12!\n" +
+	"\fnot_modified\x18\x02 \x01(\bR\vnotModified2\xc9\x01\n" +
+	"\rLessonService\x12U\n" +
+	"\tGetLesson\x12 .opendavinci.v1.GetLessonRequest\x1a!.opendavinci.v1.GetLessonResponse\"\x03\x90\x02\x01\x12a\n" +
+	"\rGetLessonHTML\x12$.opendavinci.v1.GetLessonHTMLRequest\x1a%.opendavinci.v1.GetLessonHTMLResponse\"\x03\x90\x02\x01B.Z,opendavinci/gen/opendavinci/v1;opendavinciv1b\x06proto3"
+
+var (
+	file_opendavinci_v1_lesson_proto_rawDescOnce sync.Once
+	file_opendavinci_v1_lesson_proto_rawDescData []byte
+)
+
+func file_opendavinci_v1_lesson_proto_rawDescGZIP() []byte {
+	file_opendavinci_v1_lesson_proto_rawDescOnce.Do(func() {
+		file_opendavinci_v1_lesson_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_opendavinci_v1_lesson_proto_rawDesc), len(file_opendavinci_v1_lesson_proto_rawDesc)))
+	})
+	return file_opendavinci_v1_lesson_proto_rawDescData
+}
+
+var file_opendavinci_v1_lesson_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_opendavinci_v1_lesson_proto_goTypes = []any{
+	(*Lesson)(nil),                // 0: opendavinci.v1.Lesson
+	(*Attachment)(nil),            // 1: opendavinci.v1.Attachment
+	(*Document)(nil),              // 2: opendavinci.v1.Document
+	(*Heading)(nil),               // 3: opendavinci.v1.Heading
+	(*GetLessonRequest)(nil),      // 4: opendavinci.v1.GetLessonRequest
+	(*GetLessonResponse)(nil),     // 5: opendavinci.v1.GetLessonResponse
+	(*GetLessonHTMLRequest)(nil),  // 6: opendavinci.v1.GetLessonHTMLRequest
+	(*GetLessonHTMLResponse)(nil), // 7: opendavinci.v1.GetLessonHTMLResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_opendavinci_v1_lesson_proto_depIdxs = []int32{
+	8, // 0: opendavinci.v1.Lesson.created:type_name -> google.protobuf.Timestamp
+	1, // 1: opendavinci.v1.Lesson.attachments:type_name -> opendavinci.v1.Attachment
+	8, // 2: opendavinci.v1.Attachment.uploaded:type_name -> google.protobuf.Timestamp
+	3, // 3: opendavinci.v1.Document.toc:type_name -> opendavinci.v1.Heading
+	0, // 4: opendavinci.v1.GetLessonResponse.lesson:type_name -> opendavinci.v1.Lesson
+	2, // 5: opendavinci.v1.GetLessonHTMLResponse.document:type_name -> opendavinci.v1.Document
+	4, // 6: opendavinci.v1.LessonService.GetLesson:input_type -> opendavinci.v1.GetLessonRequest
+	6, // 7: opendavinci.v1.LessonService.GetLessonHTML:input_type -> opendavinci.v1.GetLessonHTMLRequest
+	5, // 8: opendavinci.v1.LessonService.GetLesson:output_type -> opendavinci.v1.GetLessonResponse
+	7, // 9: opendavinci.v1.LessonService.GetLessonHTML:output_type -> opendavinci.v1.GetLessonHTMLResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_opendavinci_v1_lesson_proto_init() }
+func file_opendavinci_v1_lesson_proto_init() {
+	if File_opendavinci_v1_lesson_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_opendavinci_v1_lesson_proto_rawDesc), len(file_opendavinci_v1_lesson_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_opendavinci_v1_lesson_proto_goTypes,
+		DependencyIndexes: file_opendavinci_v1_lesson_proto_depIdxs,
+		MessageInfos:      file_opendavinci_v1_lesson_proto_msgTypes,
+	}.Build()
+	File_opendavinci_v1_lesson_proto = out.File
+	file_opendavinci_v1_lesson_proto_goTypes = nil
+	file_opendavinci_v1_lesson_proto_depIdxs = nil
+}
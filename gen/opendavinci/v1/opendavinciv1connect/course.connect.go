@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: opendavinci/v1/course.proto
+
+package opendavinciv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	v1 "opendavinci/gen/opendavinci/v1"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// CourseServiceName is the fully-qualified name of the CourseService service.
+	CourseServiceName = "opendavinci.v1.CourseService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// CourseServiceListCoursesProcedure is the fully-qualified name of the CourseService's ListCourses
+	// RPC.
+	CourseServiceListCoursesProcedure = "/opendavinci.v1.CourseService/ListCourses"
+	// CourseServiceGetCourseProcedure is the fully-qualified name of the CourseService's GetCourse RPC.
+	CourseServiceGetCourseProcedure = "/opendavinci.v1.CourseService/GetCourse"
+	// CourseServiceCreateCourseProcedure is the fully-qualified name of the CourseService's
+	// CreateCourse RPC.
+	CourseServiceCreateCourseProcedure = "/opendavinci.v1.CourseService/CreateCourse"
+	// CourseServiceUpdateCourseProcedure is the fully-qualified name of the CourseService's
+	// UpdateCourse RPC.
+	CourseServiceUpdateCourseProcedure = "/opendavinci.v1.CourseService/UpdateCourse"
+)
+
+// CourseServiceClient is a client for the opendavinci.v1.CourseService service.
+type CourseServiceClient interface {
+	// ListCourses gets courses ordered by creation, like GET /api/v1/courses.
+	ListCourses(context.Context, *connect.Request[v1.ListCoursesRequest]) (*connect.Response[v1.ListCoursesResponse], error)
+	// GetCourse gets a course by ID, like GET /api/v1/course/{id}.
+	GetCourse(context.Context, *connect.Request[v1.GetCourseRequest]) (*connect.Response[v1.GetCourseResponse], error)
+	// CreateCourse creates a new course, like POST /api/v1/course.
+	CreateCourse(context.Context, *connect.Request[v1.CreateCourseRequest]) (*connect.Response[v1.CreateCourseResponse], error)
+	// UpdateCourse updates the course with the ID of the given one, like PUT /api/v1/course.
+	UpdateCourse(context.Context, *connect.Request[v1.UpdateCourseRequest]) (*connect.Response[v1.UpdateCourseResponse], error)
+}
+
+// NewCourseServiceClient constructs a client for the opendavinci.v1.CourseService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewCourseServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) CourseServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	courseServiceMethods := v1.File_opendavinci_v1_course_proto.Services().ByName("CourseService").Methods()
+	return &courseServiceClient{
+		listCourses: connect.NewClient[v1.ListCoursesRequest, v1.ListCoursesResponse](
+			httpClient,
+			baseURL+CourseServiceListCoursesProcedure,
+			connect.WithSchema(courseServiceMethods.ByName("ListCourses")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getCourse: connect.NewClient[v1.GetCourseRequest, v1.GetCourseResponse](
+			httpClient,
+			baseURL+CourseServiceGetCourseProcedure,
+			connect.WithSchema(courseServiceMethods.ByName("GetCourse")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		createCourse: connect.NewClient[v1.CreateCourseRequest, v1.CreateCourseResponse](
+			httpClient,
+			baseURL+CourseServiceCreateCourseProcedure,
+			connect.WithSchema(courseServiceMethods.ByName("CreateCourse")),
+			connect.WithClientOptions(opts...),
+		),
+		updateCourse: connect.NewClient[v1.UpdateCourseRequest, v1.UpdateCourseResponse](
+			httpClient,
+			baseURL+CourseServiceUpdateCourseProcedure,
+			connect.WithSchema(courseServiceMethods.ByName("UpdateCourse")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// courseServiceClient implements CourseServiceClient.
+type courseServiceClient struct {
+	listCourses  *connect.Client[v1.ListCoursesRequest, v1.ListCoursesResponse]
+	getCourse    *connect.Client[v1.GetCourseRequest, v1.GetCourseResponse]
+	createCourse *connect.Client[v1.CreateCourseRequest, v1.CreateCourseResponse]
+	updateCourse *connect.Client[v1.UpdateCourseRequest, v1.UpdateCourseResponse]
+}
+
+// ListCourses calls opendavinci.v1.CourseService.ListCourses.
+func (c *courseServiceClient) ListCourses(ctx context.Context, req *connect.Request[v1.ListCoursesRequest]) (*connect.Response[v1.ListCoursesResponse], error) {
+	return c.listCourses.CallUnary(ctx, req)
+}
+
+// GetCourse calls opendavinci.v1.CourseService.GetCourse.
+func (c *courseServiceClient) GetCourse(ctx context.Context, req *connect.Request[v1.GetCourseRequest]) (*connect.Response[v1.GetCourseResponse], error) {
+	return c.getCourse.CallUnary(ctx, req)
+}
+
+// CreateCourse calls opendavinci.v1.CourseService.CreateCourse.
+func (c *courseServiceClient) CreateCourse(ctx context.Context, req *connect.Request[v1.CreateCourseRequest]) (*connect.Response[v1.CreateCourseResponse], error) {
+	return c.createCourse.CallUnary(ctx, req)
+}
+
+// UpdateCourse calls opendavinci.v1.CourseService.UpdateCourse.
+func (c *courseServiceClient) UpdateCourse(ctx context.Context, req *connect.Request[v1.UpdateCourseRequest]) (*connect.Response[v1.UpdateCourseResponse], error) {
+	return c.updateCourse.CallUnary(ctx, req)
+}
+
+// CourseServiceHandler is an implementation of the opendavinci.v1.CourseService service.
+type CourseServiceHandler interface {
+	// ListCourses gets courses ordered by creation, like GET /api/v1/courses.
+	ListCourses(context.Context, *connect.Request[v1.ListCoursesRequest]) (*connect.Response[v1.ListCoursesResponse], error)
+	// GetCourse gets a course by ID, like GET /api/v1/course/{id}.
+	GetCourse(context.Context, *connect.Request[v1.GetCourseRequest]) (*connect.Response[v1.GetCourseResponse], error)
+	// CreateCourse creates a new course, like POST /api/v1/course.
+	CreateCourse(context.Context, *connect.Request[v1.CreateCourseRequest]) (*connect.Response[v1.CreateCourseResponse], error)
+	// UpdateCourse updates the course with the ID of the given one, like PUT /api/v1/course.
+	UpdateCourse(context.Context, *connect.Request[v1.UpdateCourseRequest]) (*connect.Response[v1.UpdateCourseResponse], error)
+}
+
+// NewCourseServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewCourseServiceHandler(svc CourseServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	courseServiceMethods := v1.File_opendavinci_v1_course_proto.Services().ByName("CourseService").Methods()
+	courseServiceListCoursesHandler := connect.NewUnaryHandler(
+		CourseServiceListCoursesProcedure,
+		svc.ListCourses,
+		connect.WithSchema(courseServiceMethods.ByName("ListCourses")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	courseServiceGetCourseHandler := connect.NewUnaryHandler(
+		CourseServiceGetCourseProcedure,
+		svc.GetCourse,
+		connect.WithSchema(courseServiceMethods.ByName("GetCourse")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	courseServiceCreateCourseHandler := connect.NewUnaryHandler(
+		CourseServiceCreateCourseProcedure,
+		svc.CreateCourse,
+		connect.WithSchema(courseServiceMethods.ByName("CreateCourse")),
+		connect.WithHandlerOptions(opts...),
+	)
+	courseServiceUpdateCourseHandler := connect.NewUnaryHandler(
+		CourseServiceUpdateCourseProcedure,
+		svc.UpdateCourse,
+		connect.WithSchema(courseServiceMethods.ByName("UpdateCourse")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/opendavinci.v1.CourseService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case CourseServiceListCoursesProcedure:
+			courseServiceListCoursesHandler.ServeHTTP(w, r)
+		case CourseServiceGetCourseProcedure:
+			courseServiceGetCourseHandler.ServeHTTP(w, r)
+		case CourseServiceCreateCourseProcedure:
+			courseServiceCreateCourseHandler.ServeHTTP(w, r)
+		case CourseServiceUpdateCourseProcedure:
+			courseServiceUpdateCourseHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedCourseServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedCourseServiceHandler struct{}
+
+func (UnimplementedCourseServiceHandler) ListCourses(context.Context, *connect.Request[v1.ListCoursesRequest]) (*connect.Response[v1.ListCoursesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("opendavinci.v1.CourseService.ListCourses is not implemented"))
+}
+
+func (UnimplementedCourseServiceHandler) GetCourse(context.Context, *connect.Request[v1.GetCourseRequest]) (*connect.Response[v1.GetCourseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("opendavinci.v1.CourseService.GetCourse is not implemented"))
+}
+
+func (UnimplementedCourseServiceHandler) CreateCourse(context.Context, *connect.Request[v1.CreateCourseRequest]) (*connect.Response[v1.CreateCourseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("opendavinci.v1.CourseService.CreateCourse is not implemented"))
+}
+
+func (UnimplementedCourseServiceHandler) UpdateCourse(context.Context, *connect.Request[v1.UpdateCourseRequest]) (*connect.Response[v1.UpdateCourseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("opendavinci.v1.CourseService.UpdateCourse is not implemented"))
+}
@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: opendavinci/v1/lesson.proto
+
+package opendavinciv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	v1 "opendavinci/gen/opendavinci/v1"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// LessonServiceName is the fully-qualified name of the LessonService service.
+	LessonServiceName = "opendavinci.v1.LessonService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// LessonServiceGetLessonProcedure is the fully-qualified name of the LessonService's GetLesson RPC.
+	LessonServiceGetLessonProcedure = "/opendavinci.v1.LessonService/GetLesson"
+	// LessonServiceGetLessonHTMLProcedure is the fully-qualified name of the LessonService's
+	// GetLessonHTML RPC.
+	LessonServiceGetLessonHTMLProcedure = "/opendavinci.v1.LessonService/GetLessonHTML"
+)
+
+// LessonServiceClient is a client for the opendavinci.v1.LessonService service.
+type LessonServiceClient interface {
+	// GetLesson gets a lesson by ID, like GET /api/v1/lesson/{id}.
+	GetLesson(context.Context, *connect.Request[v1.GetLessonRequest]) (*connect.Response[v1.GetLessonResponse], error)
+	// GetLessonHTML gets the lesson content rendered to sanitized HTML, like GET /api/v1/lesson/{id}/html.
+	GetLessonHTML(context.Context, *connect.Request[v1.GetLessonHTMLRequest]) (*connect.Response[v1.GetLessonHTMLResponse], error)
+}
+
+// NewLessonServiceClient constructs a client for the opendavinci.v1.LessonService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewLessonServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) LessonServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	lessonServiceMethods := v1.File_opendavinci_v1_lesson_proto.Services().ByName("LessonService").Methods()
+	return &lessonServiceClient{
+		getLesson: connect.NewClient[v1.GetLessonRequest, v1.GetLessonResponse](
+			httpClient,
+			baseURL+LessonServiceGetLessonProcedure,
+			connect.WithSchema(lessonServiceMethods.ByName("GetLesson")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getLessonHTML: connect.NewClient[v1.GetLessonHTMLRequest, v1.GetLessonHTMLResponse](
+			httpClient,
+			baseURL+LessonServiceGetLessonHTMLProcedure,
+			connect.WithSchema(lessonServiceMethods.ByName("GetLessonHTML")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// lessonServiceClient implements LessonServiceClient.
+type lessonServiceClient struct {
+	getLesson     *connect.Client[v1.GetLessonRequest, v1.GetLessonResponse]
+	getLessonHTML *connect.Client[v1.GetLessonHTMLRequest, v1.GetLessonHTMLResponse]
+}
+
+// GetLesson calls opendavinci.v1.LessonService.GetLesson.
+func (c *lessonServiceClient) GetLesson(ctx context.Context, req *connect.Request[v1.GetLessonRequest]) (*connect.Response[v1.GetLessonResponse], error) {
+	return c.getLesson.CallUnary(ctx, req)
+}
+
+// GetLessonHTML calls opendavinci.v1.LessonService.GetLessonHTML.
+func (c *lessonServiceClient) GetLessonHTML(ctx context.Context, req *connect.Request[v1.GetLessonHTMLRequest]) (*connect.Response[v1.GetLessonHTMLResponse], error) {
+	return c.getLessonHTML.CallUnary(ctx, req)
+}
+
+// LessonServiceHandler is an implementation of the opendavinci.v1.LessonService service.
+type LessonServiceHandler interface {
+	// GetLesson gets a lesson by ID, like GET /api/v1/lesson/{id}.
+	GetLesson(context.Context, *connect.Request[v1.GetLessonRequest]) (*connect.Response[v1.GetLessonResponse], error)
+	// GetLessonHTML gets the lesson content rendered to sanitized HTML, like GET /api/v1/lesson/{id}/html.
+	GetLessonHTML(context.Context, *connect.Request[v1.GetLessonHTMLRequest]) (*connect.Response[v1.GetLessonHTMLResponse], error)
+}
+
+// NewLessonServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewLessonServiceHandler(svc LessonServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	lessonServiceMethods := v1.File_opendavinci_v1_lesson_proto.Services().ByName("LessonService").Methods()
+	lessonServiceGetLessonHandler := connect.NewUnaryHandler(
+		LessonServiceGetLessonProcedure,
+		svc.GetLesson,
+		connect.WithSchema(lessonServiceMethods.ByName("GetLesson")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	lessonServiceGetLessonHTMLHandler := connect.NewUnaryHandler(
+		LessonServiceGetLessonHTMLProcedure,
+		svc.GetLessonHTML,
+		connect.WithSchema(lessonServiceMethods.ByName("GetLessonHTML")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/opendavinci.v1.LessonService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case LessonServiceGetLessonProcedure:
+			lessonServiceGetLessonHandler.ServeHTTP(w, r)
+		case LessonServiceGetLessonHTMLProcedure:
+			lessonServiceGetLessonHTMLHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedLessonServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedLessonServiceHandler struct{}
+
+func (UnimplementedLessonServiceHandler) GetLesson(context.Context, *connect.Request[v1.GetLessonRequest]) (*connect.Response[v1.GetLessonResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("opendavinci.v1.LessonService.GetLesson is not implemented"))
+}
+
+func (UnimplementedLessonServiceHandler) GetLessonHTML(context.Context, *connect.Request[v1.GetLessonHTMLRequest]) (*connect.Response[v1.GetLessonHTMLResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("opendavinci.v1.LessonService.GetLessonHTML is not implemented"))
+}
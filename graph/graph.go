@@ -0,0 +1,179 @@
+// Package graph serves courses, lessons, users and enrollments over GraphQL.
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"opendavinci/logging"
+	"opendavinci/problem"
+)
+
+// Request struct to describe a GraphQL request, sent as JSON body or query parameters.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Viewer struct to describe who sends the request, from claims of the access token.
+type Viewer struct {
+	Authenticated bool
+	Subject       string // sub claim, the user ID
+	Role          string // role claim, e.g. admin
+}
+
+// Owns method for checking if the viewer may see private fields of the user with id.
+func (v Viewer) Owns(id string) bool {
+	return v.Authenticated && (v.Subject == id || v.Role == "admin")
+}
+
+var (
+	schemaOnce sync.Once
+	schema     graphql.Schema
+	schemaErr  error
+)
+
+// Schema func for getting the schema, it is built once.
+func Schema() (graphql.Schema, error) {
+	schemaOnce.Do(func() {
+		schema, schemaErr = newSchema()
+	})
+
+	return schema, schemaErr
+}
+
+// Execute func for running the request within limits as viewer.
+// Valid is false, if the request was rejected before it ran, e.g. it has a syntax error.
+func Execute(ctx context.Context, req Request, viewer Viewer, limits Limits) (res *graphql.Result, valid bool) {
+	s, err := Schema()
+	if err != nil {
+		return rejected(problem.Internal(err)), false
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: withCodes(gqlerrors.FormatErrors(err), problem.CodeGraphQLInvalid)}, false
+	}
+
+	if v := graphql.ValidateDocument(&s, doc, nil); !v.IsValid {
+		return &graphql.Result{Errors: withCodes(v.Errors, problem.CodeGraphQLInvalid)}, false
+	}
+
+	depth, complexity := measure(s, doc, req.OperationName, req.Variables)
+	if depth > limits.MaxDepth {
+		return rejected(problem.BadRequest(problem.CodeGraphQLTooDeep,
+			fmt.Sprintf("query depth %d exceeds the limit of %d", depth, limits.MaxDepth))), false
+	}
+	if complexity > limits.MaxComplexity {
+		return rejected(problem.BadRequest(problem.CodeGraphQLTooComplex,
+			fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, limits.MaxComplexity))), false
+	}
+
+	ctx = context.WithValue(ctx, stateKey{}, &state{viewer: viewer, loaders: newLoaders(ctx)})
+	res = graphql.Execute(graphql.ExecuteParams{
+		Schema:        s,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	for i := range res.Errors {
+		res.Errors[i] = formatError(ctx, res.Errors[i])
+	}
+
+	return res, true
+}
+
+// state struct to describe the viewer and the loaders of one request.
+type state struct {
+	viewer  Viewer
+	loaders *loaders
+}
+
+type stateKey struct{}
+
+// stateOf func for getting the state of the request from the context of resolvers.
+func stateOf(ctx context.Context) *state {
+	s, _ := ctx.Value(stateKey{}).(*state)
+	if s == nil {
+		return &state{loaders: newLoaders(ctx)}
+	}
+
+	return s
+}
+
+// rejected func for the result of a request which did not run.
+func rejected(p *problem.Problem) *graphql.Result {
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{{
+		Message:    message(p),
+		Extensions: map[string]any{"code": p.Code},
+	}}}
+}
+
+// withCodes func for setting the code extension of errors.
+func withCodes(errs []gqlerrors.FormattedError, code string) []gqlerrors.FormattedError {
+	for i := range errs {
+		errs[i].Extensions = map[string]any{"code": code}
+	}
+
+	return errs
+}
+
+// formatError func for sending problems of resolvers with their stable code,
+// causes of internal problems are logged, but not sent.
+func formatError(ctx context.Context, fe gqlerrors.FormattedError) gqlerrors.FormattedError {
+	p := problemOf(fe)
+	if p == nil {
+		return fe
+	}
+
+	if p.Status >= 500 {
+		logging.FromContext(ctx).Error("GraphQL field failed", "error", p, "path", fe.Path)
+	}
+	fe.Message = message(p)
+	fe.Extensions = map[string]any{"code": p.Code}
+
+	return fe
+}
+
+// problemOf func for finding the problem which caused a field error.
+// The executor wraps errors of resolvers and thunks differently, and not with %w.
+func problemOf(err error) *problem.Problem {
+	for err != nil {
+		var p *problem.Problem
+		if errors.As(err, &p) {
+			return p
+		}
+
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			err = e.OriginalError
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// message func for the message of a problem, its detail or title.
+func message(p *problem.Problem) string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+
+	return p.Title
+}
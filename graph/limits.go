@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits struct to describe how expensive a query may be, checked before it runs.
+type Limits struct {
+	MaxDepth      int // nesting of fields, e.g. 3 for courses { lessons { title } }
+	MaxComplexity int // fields a query may resolve, list fields count per item
+}
+
+// listSize is the assumed number of items of list fields without a limit argument,
+// lists in a page, e.g. courses(limit: 50) { courses { ... } }, have the limit of the page.
+const listSize = 10
+
+// cost struct to describe the walk of a query for its depth and complexity.
+type cost struct {
+	schema    graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// measure func for getting the depth and complexity of the operation,
+// fields of introspection are free, tools must be able to load the schema.
+func measure(schema graphql.Schema, doc *ast.Document, operation string, variables map[string]any) (depth, complexity int) {
+	w := &cost{schema: schema, fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			w.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if op == nil && (operation == "" || (def.Name != nil && def.Name.Value == operation)) {
+				op = def
+			}
+		}
+	}
+	if op == nil {
+		return 0, 0
+	}
+
+	root := schema.QueryType()
+	if op.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+	if root == nil {
+		return 0, 0
+	}
+
+	return w.selections(op.SelectionSet, root, map[string]bool{}, listSize)
+}
+
+// selections method for the depth and complexity of set on values of parent.
+// Fragments add no depth, spreads is the path of fragments to stop cycles,
+// size is the number of items of lists without a limit argument.
+func (w *cost) selections(set *ast.SelectionSet, parent *graphql.Object, spreads map[string]bool, size int) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, sel := range set.Selections {
+		var d, c int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			d, c = w.field(sel, parent, spreads, size)
+		case *ast.InlineFragment:
+			d, c = w.selections(sel.SelectionSet, w.object(sel.TypeCondition, parent), spreads, size)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag, ok := w.fragments[name]
+			if !ok || spreads[name] {
+				continue
+			}
+			spreads[name] = true
+			d, c = w.selections(frag.SelectionSet, w.object(frag.TypeCondition, parent), spreads, size)
+			delete(spreads, name)
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+// field method for the depth and complexity of f and its sub fields.
+func (w *cost) field(f *ast.Field, parent *graphql.Object, spreads map[string]bool, size int) (depth, complexity int) {
+	if strings.HasPrefix(f.Name.Value, "__") {
+		return 0, 0
+	}
+	def, ok := parent.Fields()[f.Name.Value]
+	if !ok {
+		return 1, 1
+	}
+
+	limit, limited := w.limit(f)
+	typ, items := def.Type, 1
+	if nonNull, ok := typ.(*graphql.NonNull); ok {
+		typ = nonNull.OfType
+	}
+	if list, ok := typ.(*graphql.List); ok {
+		typ, items = list.OfType, size
+		if limited {
+			items = limit
+		}
+		size = listSize
+	} else if limited {
+		size = limit
+	}
+	if nonNull, ok := typ.(*graphql.NonNull); ok {
+		typ = nonNull.OfType
+	}
+
+	object, ok := typ.(*graphql.Object)
+	if !ok {
+		return 1, 1
+	}
+	d, c := w.selections(f.SelectionSet, object, spreads, size)
+
+	return 1 + d, 1 + items*c
+}
+
+// limit method for the value of the limit argument of f, if it has one.
+func (w *cost) limit(f *ast.Field) (int, bool) {
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n, true
+			}
+		case *ast.Variable:
+			if n, ok := w.variables[v.Name.Value].(float64); ok && n > 0 {
+				return int(n), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// object method for the type of a fragment condition, parent if it has none.
+func (w *cost) object(cond *ast.Named, parent *graphql.Object) *graphql.Object {
+	if cond == nil {
+		return parent
+	}
+	if object, ok := w.schema.Type(cond.Name.Value).(*graphql.Object); ok {
+		return object
+	}
+
+	return parent
+}
@@ -0,0 +1,78 @@
+package graph
+
+import "sync"
+
+// Loader struct to describe batched loading of values by key within one request,
+// it avoids a query per parent object (N+1) when resolving nested fields.
+//
+// Load only collects the key and returns a thunk. The executor resolves all
+// fields of one level before it calls their thunks, so the first thunk
+// fetches the keys of the whole level with one call of batch.
+type Loader[K comparable, V any] struct {
+	batch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+// NewLoader func for creating a loader, batch returns the values of found keys.
+func NewLoader[K comparable, V any](batch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:  batch,
+		queued: map[K]bool{},
+		values: map[K]V{},
+		errs:   map[K]error{},
+	}
+}
+
+// Load method for queueing key, the thunk returns its value, or the zero value if not found.
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			l.flush()
+		}
+
+		return l.values[key], l.errs[key]
+	}
+}
+
+// Prime method for caching a value which was loaded otherwise, e.g. by a root field.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.values[key] = value
+	}
+}
+
+// flush method for fetching all pending keys with one batch, the lock is held.
+func (l *Loader[K, V]) flush() {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if v, ok := values[key]; ok {
+			l.values[key] = v
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"opendavinci/database"
+	"opendavinci/models"
+	"opendavinci/problem"
+)
+
+// loaders struct to describe the batched lookups of nested fields, one set per request.
+type loaders struct {
+	courseByID          *Loader[uuid.UUID, *models.Course]
+	courseByKey         *Loader[string, *models.Course]
+	lessonsByCourse     *Loader[string, []models.Lesson]
+	userByID            *Loader[uuid.UUID, *models.User]
+	enrollmentsByCourse *Loader[uuid.UUID, []models.Enrollment]
+	enrollmentsByUser   *Loader[uuid.UUID, []models.Enrollment]
+}
+
+// newLoaders func for creating the loaders of a request, their queries run with ctx.
+func newLoaders(ctx context.Context) *loaders {
+	return &loaders{
+		courseByID: NewLoader(func(ids []uuid.UUID) (map[uuid.UUID]*models.Course, error) {
+			db, err := database.OpenDBConnection(ctx)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			courses, err := db.GetCoursesByIDs(ids)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			return index(courses, func(c *models.Course) uuid.UUID { return c.ID }), nil
+		}),
+		courseByKey: NewLoader(func(keys []string) (map[string]*models.Course, error) {
+			db, err := database.OpenDBConnection(ctx)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			courses, err := db.GetCoursesByKeys(keys)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			return index(courses, func(c *models.Course) string { return c.CourseID }), nil
+		}),
+		lessonsByCourse: NewLoader(func(keys []string) (map[string][]models.Lesson, error) {
+			db, err := database.OpenDBConnection(ctx)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			lessons, err := db.GetLessonsByCourses(keys)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			return group(lessons, func(l models.Lesson) string { return l.CourseID }), nil
+		}),
+		userByID: NewLoader(func(ids []uuid.UUID) (map[uuid.UUID]*models.User, error) {
+			db, err := database.OpenDBConnection(ctx)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			users, err := db.GetUsersByIDs(ids)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			return index(users, func(u *models.User) uuid.UUID { return u.ID }), nil
+		}),
+		enrollmentsByCourse: NewLoader(func(ids []uuid.UUID) (map[uuid.UUID][]models.Enrollment, error) {
+			db, err := database.OpenDBConnection(ctx)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			enrollments, err := db.GetEnrollmentsByCourses(ids)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			return group(enrollments, func(e models.Enrollment) uuid.UUID { return e.CourseID }), nil
+		}),
+		enrollmentsByUser: NewLoader(func(ids []uuid.UUID) (map[uuid.UUID][]models.Enrollment, error) {
+			db, err := database.OpenDBConnection(ctx)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			enrollments, err := db.GetEnrollmentsByUsers(ids)
+			if err != nil {
+				return nil, problem.From(err)
+			}
+			return group(enrollments, func(e models.Enrollment) uuid.UUID { return e.UserID }), nil
+		}),
+	}
+}
+
+// index func for mapping rows by their key.
+func index[K comparable, V any](rows []V, key func(*V) K) map[K]*V {
+	m := make(map[K]*V, len(rows))
+	for i := range rows {
+		m[key(&rows[i])] = &rows[i]
+	}
+
+	return m
+}
+
+// group func for grouping rows by their key, keeping their order.
+func group[K comparable, V any](rows []V, key func(V) K) map[K][]V {
+	m := map[K][]V{}
+	for _, row := range rows {
+		m[key(row)] = append(m[key(row)], row)
+	}
+
+	return m
+}
@@ -0,0 +1,342 @@
+package graph
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+
+	"opendavinci/database"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/queries"
+)
+
+// maxCoursesPerPage is the largest limit of the courses query, like of GET /api/v1/courses.
+const maxCoursesPerPage = 100
+
+// coursePage struct to describe a page of courses ordered by creation.
+type coursePage struct {
+	Courses    []models.Course `json:"courses"`
+	NextCursor *string         `json:"nextCursor"`
+}
+
+// newSchema func for building the types and resolvers of the schema.
+// Fields without resolver are read from the json tag of models.
+func newSchema() (graphql.Schema, error) {
+	var courseType, lessonType, userType, enrollmentType *graphql.Object
+
+	courseType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Course",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: graphql.NewNonNull(graphql.ID)},
+				"courseId":    {Type: graphql.NewNonNull(graphql.String)},
+				"title":       {Type: graphql.NewNonNull(graphql.String)},
+				"description": {Type: graphql.NewNonNull(graphql.String)},
+				"instructor":  {Type: graphql.NewNonNull(graphql.String)},
+				"subject":     {Type: graphql.NewNonNull(graphql.String)},
+				"image":       {Type: graphql.NewNonNull(graphql.String)},
+				"published":   {Type: graphql.NewNonNull(graphql.String)},
+				"updated":     {Type: graphql.NewNonNull(graphql.String)},
+				"created":     {Type: graphql.NewNonNull(graphql.DateTime)},
+				"lessons": {
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(lessonType))),
+					Description: "Lessons of the course ordered by position.",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						course := sourceOf[models.Course](p)
+						return thunk(stateOf(p.Context).loaders.lessonsByCourse.Load(course.CourseID)), nil
+					},
+				},
+				"enrollments": {
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(enrollmentType))),
+					Description: "Enrollments in the course, admins see all, other users their own.",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						viewer := stateOf(p.Context).viewer
+						if !viewer.Authenticated {
+							return nil, tokenRequired()
+						}
+						course := sourceOf[models.Course](p)
+						load := stateOf(p.Context).loaders.enrollmentsByCourse.Load(course.ID)
+						return func() (any, error) {
+							enrollments, err := load()
+							if err != nil {
+								return nil, err
+							}
+							visible := []models.Enrollment{}
+							for _, e := range enrollments {
+								if viewer.Owns(e.UserID.String()) {
+									visible = append(visible, e)
+								}
+							}
+							return visible, nil
+						}, nil
+					},
+				},
+			}
+		}),
+	})
+
+	lessonType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Lesson",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          {Type: graphql.NewNonNull(graphql.ID)},
+				"lessonId":    {Type: graphql.NewNonNull(graphql.String)},
+				"courseId":    {Type: graphql.NewNonNull(graphql.String)},
+				"position":    {Type: graphql.NewNonNull(graphql.Int)},
+				"title":       {Type: graphql.NewNonNull(graphql.String)},
+				"content":     {Type: graphql.NewNonNull(graphql.String)},
+				"format":      {Type: graphql.NewNonNull(graphql.String)},
+				"resourceUrl": {Type: graphql.NewNonNull(graphql.String)},
+				"created":     {Type: graphql.NewNonNull(graphql.DateTime)},
+				"course": {
+					Type: courseType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						lesson := sourceOf[models.Lesson](p)
+						return thunk(stateOf(p.Context).loaders.courseByKey.Load(lesson.CourseID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "Private fields are visible to the user and admins.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      {Type: graphql.NewNonNull(graphql.ID)},
+				"created": {Type: graphql.NewNonNull(graphql.DateTime)},
+				"email": {
+					Type: graphql.String,
+					Resolve: owner(func(p graphql.ResolveParams) (any, error) {
+						return sourceOf[models.User](p).Email, nil
+					}),
+				},
+				"role": {
+					Type: graphql.String,
+					Resolve: owner(func(p graphql.ResolveParams) (any, error) {
+						return sourceOf[models.User](p).Role, nil
+					}),
+				},
+				"enrollments": {
+					Type: graphql.NewList(graphql.NewNonNull(enrollmentType)),
+					Resolve: owner(func(p graphql.ResolveParams) (any, error) {
+						user := sourceOf[models.User](p)
+						return thunk(stateOf(p.Context).loaders.enrollmentsByUser.Load(user.ID)), nil
+					}),
+				},
+			}
+		}),
+	})
+
+	enrollmentType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Enrollment",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        {Type: graphql.NewNonNull(graphql.ID)},
+				"created":   {Type: graphql.NewNonNull(graphql.DateTime)},
+				"progress":  {Type: graphql.NewNonNull(graphql.Int), Description: "Percent of the course done."},
+				"completed": {Type: graphql.DateTime},
+				"user": {
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						e := sourceOf[models.Enrollment](p)
+						return thunk(stateOf(p.Context).loaders.userByID.Load(e.UserID)), nil
+					},
+				},
+				"course": {
+					Type: courseType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						e := sourceOf[models.Enrollment](p)
+						return thunk(stateOf(p.Context).loaders.courseByID.Load(e.CourseID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	coursePageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CoursePage",
+		Fields: graphql.Fields{
+			"courses":    {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(courseType)))},
+			"nextCursor": {Type: graphql.String, Description: "Cursor of the next page, null on the last one."},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"course": {
+				Type: courseType,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, err := idArg(p)
+					if err != nil {
+						return nil, err
+					}
+					return thunk(stateOf(p.Context).loaders.courseByID.Load(id)), nil
+				},
+			},
+			"courses": {
+				Type:        graphql.NewNonNull(coursePageType),
+				Description: "Courses ordered by creation, in pages.",
+				Args: graphql.FieldConfigArgument{
+					"limit":  {Type: graphql.Int, DefaultValue: 20},
+					"cursor": {Type: graphql.String, Description: "nextCursor of the previous page"},
+				},
+				Resolve: resolveCourses,
+			},
+			"lesson": {
+				Type: lessonType,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, err := idArg(p)
+					if err != nil {
+						return nil, err
+					}
+					db, err := database.OpenDBConnection(p.Context)
+					if err != nil {
+						return nil, problem.From(err)
+					}
+					lesson, err := db.GetLesson(id)
+					if errors.Is(err, sql.ErrNoRows) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, problem.From(err)
+					}
+					return lesson, nil
+				},
+			},
+			"user": {
+				Type: userType,
+				Args: graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: authenticated(func(p graphql.ResolveParams) (any, error) {
+					id, err := idArg(p)
+					if err != nil {
+						return nil, err
+					}
+					return thunk(stateOf(p.Context).loaders.userByID.Load(id)), nil
+				}),
+			},
+			"me": {
+				Type:        userType,
+				Description: "User of the access token, null if the token has no subject.",
+				Resolve: authenticated(func(p graphql.ResolveParams) (any, error) {
+					id, err := uuid.Parse(stateOf(p.Context).viewer.Subject)
+					if err != nil {
+						return nil, nil
+					}
+					return thunk(stateOf(p.Context).loaders.userByID.Load(id)), nil
+				}),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// resolveCourses func for the courses query, the loader of courses by ID is primed with the page.
+func resolveCourses(p graphql.ResolveParams) (any, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > maxCoursesPerPage {
+		return nil, problem.BadRequest(problem.CodeInvalidQuery, fmt.Sprintf("limit must be from 1 to %d", maxCoursesPerPage))
+	}
+	cursor, _ := p.Args["cursor"].(string)
+	after, err := queries.ParseCourseCursor(cursor)
+	if err != nil {
+		return nil, problem.BadRequest(problem.CodeInvalidQuery, "cursor is malformed")
+	}
+
+	db, err := database.OpenDBConnection(p.Context)
+	if err != nil {
+		return nil, problem.From(err)
+	}
+	courses, err := db.GetCoursesAfter(after, limit+1)
+	if err != nil {
+		return nil, problem.From(err)
+	}
+
+	page := coursePage{Courses: courses}
+	if len(courses) > limit {
+		page.Courses = courses[:limit]
+		next := queries.CourseCursorOf(courses[limit-1]).String()
+		page.NextCursor = &next
+	}
+
+	loaders := stateOf(p.Context).loaders
+	for i := range page.Courses {
+		loaders.courseByID.Prime(page.Courses[i].ID, &page.Courses[i])
+	}
+
+	return page, nil
+}
+
+// sourceOf func for getting the model of the parent object, as value or pointer.
+func sourceOf[T any](p graphql.ResolveParams) T {
+	switch v := p.Source.(type) {
+	case T:
+		return v
+	case *T:
+		return *v
+	}
+
+	var zero T
+	return zero
+}
+
+// thunk func for deferring a field to the batch of its loader.
+func thunk[V any](load func() (V, error)) func() (any, error) {
+	return func() (any, error) {
+		v, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// idArg func for parsing the id argument.
+func idArg(p graphql.ResolveParams) (uuid.UUID, error) {
+	raw, _ := p.Args["id"].(string)
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, problem.InvalidID("id", err)
+	}
+
+	return id, nil
+}
+
+// authenticated func for resolving a field only for requests with a valid access token.
+func authenticated(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		if !stateOf(p.Context).viewer.Authenticated {
+			return nil, tokenRequired()
+		}
+		return resolve(p)
+	}
+}
+
+// owner func for resolving a private field of users only for the user and admins.
+func owner(resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		viewer := stateOf(p.Context).viewer
+		if !viewer.Authenticated {
+			return nil, tokenRequired()
+		}
+		if !viewer.Owns(sourceOf[models.User](p).ID.String()) {
+			return nil, problem.New(http.StatusForbidden, problem.CodeForbidden, "field is visible to the user and admins only")
+		}
+		return resolve(p)
+	}
+}
+
+// tokenRequired func for the problem of private fields in requests without access token.
+func tokenRequired() *problem.Problem {
+	return problem.Unauthorized(problem.CodeTokenMissing, "access token is required for this field")
+}
@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status values of checks and reports.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check func to describe one readiness check, nil error means ready.
+type Check func(ctx context.Context) error
+
+// Reasons of failed checks, checks give their own with Fail.
+const (
+	ReasonFailed        = "failed"
+	ReasonTimeout       = "timeout"
+	ReasonNotRegistered = "not_registered"
+)
+
+// Result struct to describe the outcome of one check.
+// Probes are public, so the error is only kept for logging and the response has a stable reason.
+type Result struct {
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+	Error      error  `json:"-"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// failure struct to describe an error of a check with its reason.
+type failure struct {
+	reason string
+	err    error
+}
+
+func (f *failure) Error() string { return f.err.Error() }
+func (f *failure) Unwrap() error { return f.err }
+
+// Fail func for an error of a check with a stable reason, e.g. migration_dirty.
+func Fail(reason string, err error) error {
+	return &failure{reason: reason, err: err}
+}
+
+// reasonOf func for getting the reason of a failed check.
+func reasonOf(err error) string {
+	var f *failure
+	switch {
+	case errors.As(err, &f):
+		return f.reason
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	default:
+		return ReasonFailed
+	}
+}
+
+// Report struct to describe the outcome of all checks.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+var (
+	mu     sync.RWMutex
+	checks = map[string]Check{}
+)
+
+// Register func for adding a readiness check, checks are replaced by name.
+func Register(name string, check Check) {
+	mu.Lock()
+	checks[name] = check
+	mu.Unlock()
+}
+
+// Names func for getting names of registered checks in order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Run func for running the named checks in parallel, each within timeout.
+// All registered checks are run, if no names are given.
+func Run(ctx context.Context, timeout time.Duration, names ...string) *Report {
+	if len(names) == 0 {
+		names = Names()
+	}
+
+	report := &Report{Status: StatusOK, Checks: make(map[string]Result, len(names))}
+
+	var wg sync.WaitGroup
+	var resultMu sync.Mutex
+	for _, name := range names {
+		mu.RLock()
+		check, ok := checks[name]
+		mu.RUnlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			result := Result{Status: StatusOK}
+			if !ok {
+				result = Result{Status: StatusFail, Reason: ReasonNotRegistered, Error: errors.New("check is not registered")}
+			} else if err := run(ctx, timeout, check); err != nil {
+				result = Result{Status: StatusFail, Reason: reasonOf(err), Error: err}
+			}
+			result.DurationMs = time.Since(start).Milliseconds()
+
+			resultMu.Lock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+			resultMu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run calls check, it returns on timeout even if check ignores the context.
+func run(ctx context.Context, timeout time.Duration, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- check(ctx)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,147 @@
+// Package jobs runs background work queued in the jobs table of Postgres.
+// Jobs are queued with Enqueue, in the transaction of the change they are for,
+// so a job exists if and only if the change was committed. Workers claim due jobs
+// of the kinds registered in their process with FOR UPDATE SKIP LOCKED and run their handlers.
+// Failed jobs are retried with exponential backoff, after the last attempt they are dead
+// until an admin queues them again.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"opendavinci/database"
+	"opendavinci/models"
+)
+
+// Args is implemented by the arguments of a job kind, they are stored as JSON.
+type Args interface {
+	Kind() string // e.g. webhook.deliver
+}
+
+// Job struct to describe an attempt of a job with typed arguments.
+type Job[A Args] struct {
+	ID          int64
+	Attempt     int       // 1 for the first attempt
+	MaxAttempts int       // the job is dead, if this attempt fails
+	RetryAt     time.Time // when a failed attempt is retried, zero on the last one
+	Args        A
+}
+
+// Options struct to describe the retries of a job kind, zero values are the defaults of config.
+type Options struct {
+	MaxAttempts int           // JOBS_MAX_ATTEMPTS
+	RetryMin    time.Duration // JOBS_RETRY_MIN, doubled after each failed attempt
+	RetryMax    time.Duration // JOBS_RETRY_MAX
+	Timeout     time.Duration // of one attempt, at most JOBS_LEASE
+}
+
+// kind struct to describe the handler of a registered job kind.
+type kind struct {
+	opts Options
+	run  func(ctx context.Context, j models.Job, maxAttempts int, retryAt time.Time) error
+}
+
+// periodic struct to describe a job queued again and again.
+type periodic struct {
+	args  Args
+	every time.Duration
+}
+
+var (
+	registryMu sync.Mutex
+	kinds      = map[string]*kind{}
+	schedule   []periodic
+)
+
+// Register func for handling jobs of the kind of A by handle, before the worker is started.
+// Handlers may run more than once for a job, e.g. if a worker stops before it records the outcome.
+func Register[A Args](handle func(ctx context.Context, job *Job[A]) error, opts Options) {
+	var zero A
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	kinds[zero.Kind()] = &kind{
+		opts: opts,
+		run: func(ctx context.Context, j models.Job, maxAttempts int, retryAt time.Time) error {
+			var args A
+			if err := json.Unmarshal(j.Args, &args); err != nil {
+				return Permanent(fmt.Errorf("invalid arguments: %w", err))
+			}
+
+			return handle(ctx, &Job[A]{
+				ID:          j.ID,
+				Attempt:     j.Attempts,
+				MaxAttempts: maxAttempts,
+				RetryAt:     retryAt,
+				Args:        args,
+			})
+		},
+	}
+}
+
+// Periodic func for queueing a job with args every interval, before the worker is started.
+// Workers of all instances queue it with the same unique key, so one of them is pending at most.
+func Periodic(args Args, every time.Duration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	schedule = append(schedule, periodic{args: args, every: every})
+}
+
+// periodicKey is the unique key of periodic jobs.
+const periodicKey = "periodic"
+
+// EnqueueOptions struct to describe how a job is queued.
+type EnqueueOptions struct {
+	UniqueKey string    // the job is not queued, if one of its kind with the key is pending or running
+	RunAt     time.Time // default is now
+}
+
+// Enqueue func for queueing a job with args, call it with the queries of InTx
+// to queue it together with the change it is for. It reports false, if the job is a duplicate
+// by its unique key. Wake runs the job at once, if this process has a worker.
+func Enqueue(db *database.Queries, args Args, opts *EnqueueOptions) (bool, error) {
+	if opts == nil {
+		opts = &EnqueueOptions{}
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return false, err
+	}
+
+	j := &models.Job{Kind: args.Kind(), Args: data, RunAt: opts.RunAt}
+	if j.RunAt.IsZero() {
+		j.RunAt = time.Now()
+	}
+	if opts.UniqueKey != "" {
+		j.UniqueKey = &opts.UniqueKey
+	}
+
+	return db.InsertJob(j)
+}
+
+// permanentError struct to describe a failure which retries do not fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent func for failing a job without retries, e.g. if its arguments are invalid.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// isPermanent func for checking, if err was returned by Permanent.
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
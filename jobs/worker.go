@@ -0,0 +1,337 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/metrics"
+	"opendavinci/models"
+)
+
+// maxErrorLength limits the error of a failed attempt kept with the job.
+const maxErrorLength = 1 << 10
+
+// Worker struct to describe the job loop of one process.
+type Worker struct {
+	cfg   config.Jobs
+	id    string // lockedBy of claimed jobs
+	kinds map[string]*kind
+	names []string
+
+	slots chan struct{} // one per job in progress
+	wake  chan struct{}
+	freed chan struct{}
+	wg    sync.WaitGroup
+
+	cancel    context.CancelFunc // stops claiming
+	runCancel context.CancelFunc // aborts jobs in progress
+	runCtx    context.Context
+	done      chan struct{}
+}
+
+var (
+	workerMu sync.Mutex
+	worker   *Worker
+)
+
+// sweepArgs struct to describe the periodic job deleting old done jobs.
+type sweepArgs struct{}
+
+func (sweepArgs) Kind() string { return "jobs.sweep" }
+
+// sweepInterval is how often old done jobs are deleted.
+const sweepInterval = time.Hour
+
+// Start func for running jobs of the registered kinds until Stop, the worker runs once per process.
+func Start(cfg config.Jobs) {
+	workerMu.Lock()
+	defer workerMu.Unlock()
+
+	if worker != nil {
+		return
+	}
+
+	Register(func(ctx context.Context, _ *Job[sweepArgs]) error {
+		db, err := database.OpenDBConnection(ctx)
+		if err != nil {
+			return err
+		}
+		return db.DeleteJobs(time.Now().Add(-cfg.Retention))
+	}, Options{MaxAttempts: 1})
+	Periodic(sweepArgs{}, sweepInterval)
+
+	registryMu.Lock()
+	w := &Worker{
+		cfg:   cfg,
+		id:    workerID(),
+		kinds: make(map[string]*kind, len(kinds)),
+		slots: make(chan struct{}, cfg.Concurrency),
+		wake:  make(chan struct{}, 1),
+		freed: make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+	for name, k := range kinds {
+		w.kinds[name] = &kind{opts: withDefaults(k.opts, cfg), run: k.run}
+		w.names = append(w.names, name)
+	}
+	slices.Sort(w.names)
+	jobs := slices.Clone(schedule)
+	registryMu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.runCtx, w.runCancel = context.WithCancel(context.Background())
+	worker = w
+
+	go w.loop(ctx)
+	for _, p := range jobs {
+		go w.schedule(ctx, p)
+	}
+	slog.Info("Job worker started", "worker", w.id, "kinds", w.names, "concurrency", cfg.Concurrency)
+}
+
+// Stop func for ending the worker, it waits for jobs in progress until ctx is done, then aborts them.
+// Aborted jobs are claimed again when their lease ends.
+func Stop(ctx context.Context) error {
+	workerMu.Lock()
+	w := worker
+	worker = nil
+	workerMu.Unlock()
+
+	if w == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		w.runCancel()
+		return nil
+	case <-ctx.Done():
+		w.runCancel()
+		return ctx.Err()
+	}
+}
+
+// Wake func for claiming due jobs now instead of at the next poll, e.g. after a job was queued
+// by this process. It does nothing without a worker.
+func Wake() {
+	workerMu.Lock()
+	w := worker
+	workerMu.Unlock()
+
+	if w != nil {
+		signal(w.wake)
+	}
+}
+
+// withDefaults func for setting the options left zero from config.
+func withDefaults(opts Options, cfg config.Jobs) Options {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = cfg.MaxAttempts
+	}
+	if opts.RetryMin <= 0 {
+		opts.RetryMin = cfg.RetryMin
+	}
+	if opts.RetryMax <= 0 {
+		opts.RetryMax = max(cfg.RetryMax, opts.RetryMin)
+	}
+	if opts.Timeout <= 0 || opts.Timeout > cfg.Lease {
+		opts.Timeout = cfg.Lease
+	}
+
+	return opts
+}
+
+// workerID func for naming the worker of this process in claimed jobs.
+func workerID() string {
+	host, _ := os.Hostname()
+
+	return fmt.Sprintf("%s/%d/%s", host, os.Getpid(), uuid.NewString()[:8])
+}
+
+// loop method for claiming due jobs while there are free slots, until ctx is done.
+func (w *Worker) loop(ctx context.Context) {
+	defer close(w.done)
+	defer w.wg.Wait()
+
+	t := time.NewTicker(w.cfg.PollInterval)
+	defer t.Stop()
+
+	for {
+		// Claim again as soon as a slot is free, while claims fill all free slots.
+		var freed <-chan struct{}
+		if free := cap(w.slots) - len(w.slots); free > 0 && w.claim(ctx, free) == free {
+			freed = w.freed
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-w.wake:
+		case <-freed:
+		}
+	}
+}
+
+// claim method for starting up to limit due jobs, it returns how many were claimed.
+func (w *Worker) claim(ctx context.Context, limit int) int {
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		slog.Warn("Jobs not claimed", "error", err)
+		return 0
+	}
+
+	claimed, err := db.ClaimJobs(w.names, w.id, time.Now().Add(w.cfg.Lease), limit)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Jobs not claimed", "error", err)
+		}
+		return 0
+	}
+
+	for _, j := range claimed {
+		w.slots <- struct{}{}
+		w.wg.Add(1)
+		go func() {
+			defer func() {
+				<-w.slots
+				w.wg.Done()
+				signal(w.freed)
+			}()
+			w.run(j)
+		}()
+	}
+
+	return len(claimed)
+}
+
+// run method for running the handler of job j and recording the outcome:
+// done, retried after backoff, or dead after the last attempt or a permanent error.
+func (w *Worker) run(j models.Job) {
+	logger := slog.With("job", j.ID, "kind", j.Kind, "attempt", j.Attempts)
+
+	k := w.kinds[j.Kind]
+	retryAt := nextRetry(k.opts, j.Attempts, time.Now())
+
+	ctx, cancel := context.WithTimeout(w.runCtx, k.opts.Timeout)
+	start := time.Now()
+	err := safeRun(ctx, k, j, retryAt)
+	cancel()
+	metrics.JobDuration.WithLabelValues(j.Kind).Observe(time.Since(start).Seconds())
+
+	db, dbErr := database.OpenDBConnection(w.runCtx)
+	outcome := settle(err, retryAt)
+	switch {
+	case dbErr != nil:
+	case outcome == "done":
+		dbErr = db.CompleteJob(j.ID, j.Attempts)
+	case outcome == "dead":
+		dbErr = db.BuryJob(j.ID, j.Attempts, truncate(err.Error()))
+		logger.Error("Job failed for good", "error", err)
+	default:
+		dbErr = db.RetryJob(j.ID, j.Attempts, retryAt, truncate(err.Error()))
+		logger.Warn("Job failed", "error", err, "retryAt", retryAt)
+	}
+	metrics.JobsProcessed.WithLabelValues(j.Kind, outcome).Inc()
+
+	if dbErr != nil {
+		// The lease ends and the job is claimed again.
+		logger.Error("Job outcome not recorded", "outcome", outcome, "error", dbErr)
+	}
+}
+
+// safeRun func for running the handler of k, a panic fails the attempt.
+func safeRun(ctx context.Context, k *kind, j models.Job, retryAt time.Time) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			slog.Error("Job panic", "job", j.ID, "kind", j.Kind, "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+
+	return k.run(ctx, j, k.opts.MaxAttempts, retryAt)
+}
+
+// schedule method for queueing the periodic job p now and after every interval, until ctx is done.
+func (w *Worker) schedule(ctx context.Context, p periodic) {
+	t := time.NewTicker(p.every)
+	defer t.Stop()
+
+	for {
+		db, err := database.OpenDBConnection(ctx)
+		if err == nil {
+			_, err = Enqueue(db, p.args, &EnqueueOptions{UniqueKey: periodicKey})
+		}
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Periodic job not queued", "kind", p.args.Kind(), "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// nextRetry func for when a job failing the given attempt is retried, zero after the last attempt.
+func nextRetry(opts Options, attempts int, now time.Time) time.Time {
+	if attempts >= opts.MaxAttempts {
+		return time.Time{}
+	}
+
+	return now.Add(backoff(opts, attempts))
+}
+
+// settle func for the outcome of an attempt failed with err, or done without:
+// dead without retry or with a permanent error, retried otherwise.
+func settle(err error, retryAt time.Time) string {
+	switch {
+	case err == nil:
+		return "done"
+	case retryAt.IsZero() || isPermanent(err):
+		return "dead"
+	default:
+		return "retried"
+	}
+}
+
+// backoff func for the delay after the given number of failed attempts,
+// RetryMin doubled after each one up to RetryMax.
+func backoff(opts Options, attempts int) time.Duration {
+	d := opts.RetryMin
+	for i := 1; i < attempts && d < opts.RetryMax; i++ {
+		d *= 2
+	}
+
+	return min(d, opts.RetryMax)
+}
+
+// truncate func for limiting the length of an error kept with a job.
+func truncate(s string) string {
+	if len(s) <= maxErrorLength {
+		return s
+	}
+
+	return s[:maxErrorLength]
+}
+
+// signal func for waking the receiver of ch, if it is not woken already.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
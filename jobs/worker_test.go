@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	opts := Options{RetryMin: 30 * time.Second, RetryMax: 5 * time.Minute}
+	want := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+
+	for i, w := range want {
+		if got := backoff(opts, i+1); got != w {
+			t.Errorf("backoff after attempt %d = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestNextRetry(t *testing.T) {
+	opts := Options{MaxAttempts: 3, RetryMin: time.Second, RetryMax: time.Minute}
+	now := time.Now()
+
+	if got := nextRetry(opts, 2, now); !got.Equal(now.Add(2 * time.Second)) {
+		t.Errorf("retry after attempt 2 = %s, want in 2s", got.Sub(now))
+	}
+	if got := nextRetry(opts, 3, now); !got.IsZero() {
+		t.Errorf("retry after the last attempt = %s, want none", got.Sub(now))
+	}
+}
+
+func TestSettle(t *testing.T) {
+	failed := errors.New("failed")
+	retryAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		err     error
+		retryAt time.Time
+		want    string
+	}{
+		{"done", nil, retryAt, "done"},
+		{"done on the last attempt", nil, time.Time{}, "done"},
+		{"failed", failed, retryAt, "retried"},
+		{"failed on the last attempt", failed, time.Time{}, "dead"},
+		{"failed for good", Permanent(failed), retryAt, "dead"},
+	}
+	for _, tt := range tests {
+		if got := settle(tt.err, tt.retryAt); got != tt.want {
+			t.Errorf("%s: outcome = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
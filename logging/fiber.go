@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+
+	"opendavinci/problem"
+)
+
+// jwtLocal is the key of the token set by the JWT middleware of private routes.
+const jwtLocal = "jwt"
+
+// Middleware func for logging requests in the HttpRequest format of Cloud Logging.
+// Handlers get a logger with the request ID from Ctx, entries are linked to the trace of the request.
+func Middleware(c *fiber.Ctx) error {
+	start := time.Now()
+
+	logger := slog.Default()
+	if id := RequestID(c); id != "" {
+		logger = logger.With("requestId", id)
+	}
+	c.SetUserContext(WithLogger(c.UserContext(), logger))
+
+	err := c.Next()
+
+	// Errors are turned into responses by the error handler after the middleware.
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = problem.From(err).Status
+	}
+
+	level := slog.LevelInfo
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= fiber.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	httpRequest := []any{
+		slog.String("requestMethod", c.Method()),
+		slog.String("requestUrl", RedactURL(c.OriginalURL())),
+		slog.Int("status", status),
+		slog.String("userAgent", c.Get(fiber.HeaderUserAgent)),
+		slog.String("remoteIp", c.IP()),
+		slog.String("referer", c.Get(fiber.HeaderReferer)),
+		slog.String("latency", strconv.FormatFloat(time.Since(start).Seconds(), 'f', 6, 64)+"s"),
+		slog.String("protocol", c.Protocol()),
+	}
+	if n := c.Request().Header.ContentLength(); n > 0 {
+		httpRequest = append(httpRequest, slog.String("requestSize", strconv.Itoa(n)))
+	}
+	// Reading body of a streamed response would consume the stream.
+	if !c.Response().IsBodyStream() {
+		httpRequest = append(httpRequest, slog.String("responseSize", strconv.Itoa(len(c.Response().Body()))))
+	}
+
+	attrs := []any{slog.Group("httpRequest", httpRequest...)}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	Ctx(c).Log(c.UserContext(), level, c.Method()+" "+c.Path(), attrs...)
+
+	return err
+}
+
+// Ctx func for getting the logger of the request with request ID and, once authenticated, user ID.
+func Ctx(c *fiber.Ctx) *slog.Logger {
+	logger := FromContext(c.UserContext())
+	if id := UserID(c); id != "" {
+		logger = logger.With("userId", id)
+	}
+
+	return logger
+}
+
+// RequestIDLocal is the key of the request ID set by the request ID middleware.
+const RequestIDLocal = "requestid"
+
+// RequestID func for getting ID of the request.
+func RequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(RequestIDLocal).(string)
+	return id
+}
+
+// maxRequestIDLength limits request IDs sent by callers.
+const maxRequestIDLength = 128
+
+// ValidRequestID func for checking a request ID sent by the caller is safe to log.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':', r == '/', r == '+', r == '=':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// UserID func for getting the subject of the verified access token, if any.
+func UserID(c *fiber.Ctx) string {
+	token, ok := c.Locals(jwtLocal).(*jwt.Token)
+	if !ok || !token.Valid {
+		return ""
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	sub, _ := claims["sub"].(string)
+
+	return sub
+}
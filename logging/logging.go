@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"opendavinci/config"
+)
+
+// Special fields of Cloud Logging, see https://cloud.google.com/logging/docs/structured-logging.
+const (
+	traceKey        = "logging.googleapis.com/trace"
+	spanKey         = "logging.googleapis.com/spanId"
+	traceSampledKey = "logging.googleapis.com/trace_sampled"
+)
+
+// Setup func for installing the default logger, log.Printf of other packages goes to it too.
+func Setup(cfg config.Logging) *slog.Logger {
+	logger := New(os.Stdout, cfg)
+	slog.SetDefault(logger)
+
+	return logger
+}
+
+// New func for creating a logger writing to w.
+// JSON entries use severity and message keys of Cloud Logging,
+// secrets are redacted and entries logged with a traced context are linked to their trace.
+func New(w io.Writer, cfg config.Logging) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: replaceAttr}
+
+	var h slog.Handler
+	if cfg.Format == "text" {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(&traceHandler{Handler: h, projectID: cfg.ProjectID})
+}
+
+// replaceAttr renames built-in keys for Cloud Logging and redacts secrets.
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 {
+		switch a.Key {
+		case slog.LevelKey:
+			return slog.String("severity", severity(a.Value.Any().(slog.Level)))
+		case slog.MessageKey:
+			a.Key = "message"
+			return a
+		}
+	}
+
+	return redact(a)
+}
+
+// severity func for getting the LogSeverity of Cloud Logging for level.
+func severity(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// traceHandler struct to describe handler adding trace fields from the span in context.
+type traceHandler struct {
+	slog.Handler
+	projectID string
+}
+
+// Handle method for adding trace fields to the entry.
+func (h *traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		traceID := sc.TraceID().String()
+		if h.projectID != "" {
+			traceID = "projects/" + h.projectID + "/traces/" + traceID
+		}
+		r.AddAttrs(
+			slog.String(traceKey, traceID),
+			slog.String(spanKey, sc.SpanID().String()),
+			slog.Bool(traceSampledKey, sc.IsSampled()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs method for keeping the trace handler around derived handlers.
+func (h *traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &traceHandler{Handler: h.Handler.WithAttrs(attrs), projectID: h.projectID}
+}
+
+// WithGroup method for keeping the trace handler around derived handlers.
+func (h *traceHandler) WithGroup(name string) slog.Handler {
+	return &traceHandler{Handler: h.Handler.WithGroup(name), projectID: h.projectID}
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secret values in log entries.
+const redacted = "[REDACTED]"
+
+// secretKeys are attribute, header and query parameter names which are never logged.
+var secretKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"set-cookie":    true,
+	"x-api-key":     true,
+	"api_key":       true,
+	"apikey":        true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"password":      true,
+	"secret":        true,
+	"signature":     true, // signed media URLs
+}
+
+// secretValues match bearer tokens and JWTs inside of any string, e.g. error messages.
+var secretValues = regexp.MustCompile(`(?i)bearer\s+[\w\-.~+/]+=*|eyJ[\w-]*\.[\w-]*\.[\w-]*`)
+
+// IsSecret func for checking name of header, parameter or attribute is never logged.
+func IsSecret(name string) bool {
+	return secretKeys[strings.ToLower(name)]
+}
+
+// RedactString func for replacing tokens found in s.
+func RedactString(s string) string {
+	return secretValues.ReplaceAllString(s, redacted)
+}
+
+// RedactURL func for replacing values of secret query parameters of URL.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return RedactString(rawURL)
+	}
+
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if IsSecret(key) {
+			query.Set(key, "REDACTED") // brackets would be escaped
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = query.Encode()
+	}
+
+	return RedactString(u.String())
+}
+
+// redact func for replacing secret attributes of a log entry.
+func redact(a slog.Attr) slog.Attr {
+	if IsSecret(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if s := a.Value.String(); secretValues.MatchString(s) {
+			return slog.String(a.Key, RedactString(s))
+		}
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, RedactString(err.Error()))
+		}
+	}
+
+	return a
+}
@@ -1,21 +1,23 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
 	"os"
-	"strconv"
-	"time"
 
-	"github.com/gofiber/fiber/v2"
-
-	////"opendavinci/routes"
+	"opendavinci/app"
+	"opendavinci/config"
 	"opendavinci/database"
-	"opendavinci/models"
+	"opendavinci/jobs"
+	"opendavinci/logging"
+	"opendavinci/metrics"
+	"opendavinci/routes"
+	"opendavinci/storage"
+	"opendavinci/tracing"
+	"opendavinci/webhooks"
 )
-import _ "embed"
-
-//go:embed openapi.json
-var oasJSON string
 
 // @title API
 // @version 1.0
@@ -30,74 +32,61 @@ var oasJSON string
 // @name Authorization
 // @BasePath /api
 func main() {
-	app := fiber.New(fiberConf())
+	// Load settings, stop on any invalid one.
+	conf, err := config.Load()
+	logging.Setup(conf.Logging)
+	if err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
 
-	app.Get("/api/courses", func(c *fiber.Ctx) error {
-		db, err := database.OpenDBConnection()
-		if err != nil {
-			// Return status 500 and database connection error.
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": true,
-				"msg":   err.Error(),
-			})
-		}
+	// Trace requests and queries, spans are exported only if enabled.
+	flushTraces, err := tracing.Setup(context.Background(), conf.Tracing)
+	if err != nil {
+		slog.Error("Tracing failure", "error", err)
+		os.Exit(1)
+	}
 
-		courses := []models.Course{}
-		query := `SELECT * FROM courses_v`
-		err = db.Select(&courses, query)
-		if err != nil {
-			// Return status 500 and database connection error.
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": true,
-				"msg":   err.Error(),
-			})
-		}
+	a := app.New(app.NewConfig(conf))
 
-		return c.JSON(courses)
-	})
-	app.Post("/api/courses", func(c *fiber.Ctx) error {
-		db, err := database.OpenDBConnection()
-		if err != nil {
-			// Return status 500 and database connection error.
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": true,
-				"msg":   err.Error(),
-			})
-		}
-		// todo sanitize
-		js := c.Body()
+	closers := []app.Closer{
+		{Name: "blob store", Close: func(context.Context) error { return storage.CloseBlobStore() }},
+		{Name: "database", Close: func(context.Context) error { return database.CloseDBConnection() }},
+		{Name: "tracing", Close: flushTraces},
+	}
 
-		stmt := `INSERT INTO courses (rawdata) VALUES ($1) RETURNING *`
-		ds, err := db.Exec(stmt, string(js))
-		if err != nil {
-			// Return status 500 and database connection error.
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": true,
-				"msg":   err.Error(),
-			})
+	// Run background jobs next to the API, or alone in worker mode.
+	if conf.Jobs.Mode != "api" {
+		if conf.Webhooks.Enabled {
+			webhooks.Register(conf.Webhooks)
 		}
+		if conf.Idempotency.Enabled {
+			routes.RegisterIdempotencySweep()
+		}
+		jobs.Start(conf.Jobs)
+		closers = append([]app.Closer{{Name: "jobs", Close: jobs.Stop}}, closers...)
+	}
 
-		return c.JSON(ds)
-	})
-	app.Get("/docs", func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusOK).SendString(oasJSON)
-	})
-	app.Get("/api/lessons", func(c *fiber.Ctx) error {
-		// show headers to help debug cf app token
-		dump := c.GetReqHeaders()
-		return c.Status(fiber.StatusOK).JSON(dump)
-	})
-
-	if err := app.Listen(os.Getenv("SERVER_URL")); err != nil {
-		log.Printf("Server failure: %v", err)
+	// Serve metrics on the admin port, if set.
+	if conf.Metrics.Enabled && conf.Metrics.Addr != "" {
+		srv := metrics.NewServer(conf.Metrics.Addr)
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Metrics server failure", "error", err)
+			}
+		}()
+		closers = append(closers, app.Closer{Name: "metrics server", Close: srv.Shutdown})
 	}
-}
 
-func fiberConf() fiber.Config {
-	// Define server settings.
-	readTimeoutSecondsCount, _ := strconv.Atoi(os.Getenv("SERVER_READ_TIMEOUT"))
+	// Serve gRPC over h2c on the API port, if enabled.
+	var h2c *http.Server
+	if conf.GRPC.Enabled && conf.Jobs.Mode != "worker" {
+		h2c = app.NewH2C(a, conf.GRPC.Reflection)
+	}
 
-	return fiber.Config{
-		ReadTimeout: time.Second * time.Duration(readTimeoutSecondsCount),
+	// Serve until SIGTERM, then release resources in order.
+	err = app.Run(a, h2c, conf.Server.URL, conf.Server.ShutdownTimeout, closers...)
+	if err != nil {
+		slog.Error("Server failure", "error", err)
 	}
 }
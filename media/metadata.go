@@ -0,0 +1,175 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+var errMalformed = errors.New("malformed image data")
+
+// pngSignature starts PNG data.
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// StripMetadata func for removing EXIF, XMP and text metadata from image data.
+// Pixel data is copied as is, so the EXIF orientation is kept as only tag.
+// Unsupported content types are returned unchanged.
+func StripMetadata(contentType string, data []byte) ([]byte, error) {
+	o := Orientation(data)
+
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEG(data, o)
+	case "image/png":
+		return stripPNG(data, o)
+	case "image/webp":
+		return stripWebP(data, o)
+	default:
+		return data, nil
+	}
+}
+
+// stripJPEG drops APP1 (EXIF, XMP), APP13 (IPTC) and comment segments.
+// Orientation o other than 1 is written to a new EXIF segment after the JFIF one.
+func stripJPEG(data []byte, o int) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	exif := o > 1
+	for i := 2; i < len(data); {
+		if data[i] != 0xFF || i+4 > len(data) {
+			return nil, errMalformed
+		}
+		marker := data[i+1]
+
+		// JFIF must follow the start of image, other segments may follow EXIF.
+		if exif && marker != 0xE0 {
+			segment := append([]byte(exifHeader), orientationTIFF(o)...)
+			out.Write([]byte{0xFF, 0xE1})
+			out.Write(binary.BigEndian.AppendUint16(nil, uint16(len(segment)+2)))
+			out.Write(segment)
+			exif = false
+		}
+
+		// Start of scan, the rest is entropy coded image data.
+		if marker == 0xDA {
+			out.Write(data[i:])
+			return out.Bytes(), nil
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + size
+		if size < 2 || end > len(data) {
+			return nil, errMalformed
+		}
+
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	return nil, errMalformed
+}
+
+// stripPNG drops eXIf, text and time chunks.
+// Orientation o other than 1 is written to a new eXIf chunk after the header.
+func stripPNG(data []byte, o int) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(pngSignature)) {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.WriteString(pngSignature)
+
+	for i := len(pngSignature); i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		size := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + size // length, type, data and CRC
+		if size < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+
+		switch typ := string(data[i+4 : i+8]); typ {
+		case "eXIf", "tEXt", "zTXt", "iTXt", "tIME":
+		case "IHDR":
+			out.Write(data[i:end])
+			if o > 1 {
+				writePNGChunk(out, "eXIf", orientationTIFF(o))
+			}
+		default:
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	return out.Bytes(), nil
+}
+
+// stripWebP drops EXIF and XMP chunks and clears their VP8X flags.
+// Orientation o other than 1 replaces the EXIF chunk, which only extended files have.
+func stripWebP(data []byte, o int) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformed
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size&1 // chunks are padded to even size
+		if size < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+
+		switch string(data[i : i+4]) {
+		case "XMP ":
+		case "EXIF":
+			if o > 1 {
+				tiff := orientationTIFF(o)
+				out.WriteString("EXIF")
+				out.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(tiff))))
+				out.Write(tiff) // even size, no padding
+			}
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x04 // XMP flag
+				if o <= 1 {
+					chunk[8] &^= 0x08 // EXIF flag
+				}
+			}
+			out.Write(chunk)
+		default:
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:], uint32(len(stripped)-8))
+
+	return stripped, nil
+}
+
+// writePNGChunk func for writing a chunk of typ with data and its CRC.
+func writePNGChunk(out *bytes.Buffer, typ string, data []byte) {
+	out.Write(binary.BigEndian.AppendUint32(nil, uint32(len(data))))
+	out.WriteString(typ)
+	out.Write(data)
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	out.Write(binary.BigEndian.AppendUint32(nil, crc.Sum32()))
+}
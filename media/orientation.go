@@ -0,0 +1,169 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// exifOrientation is the EXIF tag telling how the pixels are turned for display.
+const exifOrientation = 0x0112
+
+// Orientation func for getting the EXIF orientation of image data, 1 to 8.
+// Images without it, or with an invalid one, get 1, the pixels are displayed as stored.
+func Orientation(data []byte) int {
+	var tiff []byte
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		tiff = jpegEXIF(data)
+	case bytes.HasPrefix(data, []byte(pngSignature)):
+		tiff = pngChunk(data, "eXIf")
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		tiff = webpChunk(data, "EXIF")
+	}
+
+	o := tiffOrientation(bytes.TrimPrefix(tiff, []byte(exifHeader)))
+	if o < 1 || o > 8 {
+		return 1
+	}
+
+	return o
+}
+
+// exifHeader starts EXIF data in JPEG APP1 segments, some WebP files have it as well.
+const exifHeader = "Exif\x00\x00"
+
+// jpegEXIF func for getting the TIFF data of the EXIF segment of JPEG data.
+func jpegEXIF(data []byte) []byte {
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF && data[i+1] != 0xDA; {
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) {
+			return nil
+		}
+		if segment := data[i+4 : end]; data[i+1] == 0xE1 && bytes.HasPrefix(segment, []byte(exifHeader)) {
+			return segment[len(exifHeader):]
+		}
+		i = end
+	}
+
+	return nil
+}
+
+// pngChunk func for getting the data of the first chunk of typ in PNG data.
+func pngChunk(data []byte, typ string) []byte {
+	for i := len(pngSignature); i+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + size // length, type, data and CRC
+		if size < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[i+4:i+8]) == typ {
+			return data[i+8 : i+8+size]
+		}
+		i = end
+	}
+
+	return nil
+}
+
+// webpChunk func for getting the data of the first chunk of typ in WebP data.
+func webpChunk(data []byte, typ string) []byte {
+	for i := 12; i+8 <= len(data); {
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size&1 // chunks are padded to even size
+		if size < 0 || i+8+size > len(data) {
+			return nil
+		}
+		if string(data[i:i+4]) == typ {
+			return data[i+8 : i+8+size]
+		}
+		i = end
+	}
+
+	return nil
+}
+
+// tiffOrientation func for getting the orientation tag of the first IFD of TIFF data, 0 if it has none.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	n := int(order.Uint16(tiff[ifd:]))
+	for i := ifd + 2; i+12 <= len(tiff) && n > 0; i, n = i+12, n-1 {
+		if order.Uint16(tiff[i:]) == exifOrientation {
+			return int(order.Uint16(tiff[i+8:]))
+		}
+	}
+
+	return 0
+}
+
+// orientationTIFF func for TIFF data with orientation o as only tag,
+// metadata is stripped but the image is still displayed as intended.
+func orientationTIFF(o int) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08") // header, the first IFD follows
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientation)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(o))
+	tiff = binary.BigEndian.AppendUint16(tiff, 0)
+
+	return binary.BigEndian.AppendUint32(tiff, 0) // no next IFD
+}
+
+// orient func for turning the pixels of src by EXIF orientation o, so they are displayed as intended
+// without the tag.
+func orient(src image.Image, o int) image.Image {
+	if o <= 1 || o > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w // turned by 90 degrees
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		for x := range dw {
+			var sx, sy int
+			switch o {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // turned by 180 degrees
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored upside down
+				sx, sy = x, h-1-y
+			case 5: // mirrored along the diagonal
+				sx, sy = y, x
+			case 6: // turned clockwise
+				sx, sy = y, h-1-x
+			case 7: // mirrored along the other diagonal
+				sx, sy = w-1-y, h-1-x
+			case 8: // turned counterclockwise
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, src.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+
+	return dst
+}
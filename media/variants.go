@@ -0,0 +1,167 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+
+	_ "golang.org/x/image/webp" // load decoder for WebP
+	_ "image/gif"               // load decoder for GIF
+	_ "image/png"               // load decoder for PNG
+
+	"opendavinci/config"
+	"opendavinci/storage"
+)
+
+// maxPixels limits the size of decoded images to protect from decompression bombs.
+const maxPixels = 40_000_000
+
+// Variant struct to describe a resized version of an image.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int  // 0 keeps the aspect ratio of the original
+	Crop   bool // fill the whole box and crop the overflow
+}
+
+// Variants is the list of variants generated for course images.
+var Variants = []Variant{
+	{Name: "thumbnail", Width: 160, Height: 160, Crop: true},
+	{Name: "card", Width: 480, Height: 270, Crop: true},
+	{Name: "hero", Width: 1600},
+}
+
+// FindVariant func for getting variant by given name.
+func FindVariant(name string) (Variant, bool) {
+	for _, v := range Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+
+	return Variant{}, false
+}
+
+// VariantFormat func for getting the content type of generated variants.
+// Set MEDIA_VARIANT_FORMAT to "webp" to get lossless WebP instead of JPEG.
+func VariantFormat() string {
+	if config.Get().Media.VariantFormat == "webp" {
+		return "image/webp"
+	}
+
+	return "image/jpeg"
+}
+
+// VariantKey func for getting the blob key of variant of the original image.
+func VariantKey(original string, v Variant, contentType string) string {
+	return strings.TrimSuffix(original, path.Ext(original)) + "_" + v.Name + storage.Extension(contentType)
+}
+
+// Generate func for resizing the original image and storing the variant.
+// It returns the blob key of the stored variant.
+func Generate(ctx context.Context, store storage.BlobStore, original string, v Variant) (string, error) {
+	r, _, err := store.Open(ctx, original)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	contentType := VariantFormat()
+
+	buf := &bytes.Buffer{}
+	if err := Resize(r, v, buf, contentType); err != nil {
+		return "", err
+	}
+
+	key := VariantKey(original, v, contentType)
+	if err := store.Put(ctx, key, buf, contentType); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// Resize func for writing variant of image from r to w.
+// Encoding from decoded pixels drops any EXIF data of the original, its orientation is applied.
+func Resize(r io.Reader, v Variant, w io.Writer, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	size, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error, not decoded image, %w", err)
+	}
+	if size.Width == 0 || size.Height == 0 {
+		return errors.New("image is empty")
+	}
+	if size.Width*size.Height > maxPixels {
+		return errors.New("image has too many pixels")
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error, not decoded image, %w", err)
+	}
+	// Variants have no EXIF data, so the pixels are turned as the original is displayed.
+	src = orient(src, Orientation(data))
+
+	srcRect, width, height := fitRect(src.Bounds(), v)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	switch contentType {
+	case "image/webp":
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Src, nil)
+		return nativewebp.Encode(w, dst, nil)
+	case "image/jpeg":
+		// JPEG has no alpha channel, put transparent images on white.
+		draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Over, nil)
+		return jpeg.Encode(w, dst, &jpeg.Options{Quality: 82})
+	default:
+		return fmt.Errorf("unsupported variant format %q", contentType)
+	}
+}
+
+// fitRect func for getting the source rectangle and the size of variant.
+// Images are never upscaled.
+func fitRect(b image.Rectangle, v Variant) (image.Rectangle, int, int) {
+	srcW, srcH := b.Dx(), b.Dy()
+
+	if !v.Crop || v.Height == 0 {
+		width := min(v.Width, srcW)
+		height := srcH * width / srcW
+		if v.Height > 0 && height > v.Height {
+			height = v.Height
+			width = srcW * height / srcH
+		}
+		return b, max(width, 1), max(height, 1)
+	}
+
+	// Take the largest centered part of the source with the variant aspect ratio.
+	cropW, cropH := srcW, srcW*v.Height/v.Width
+	if cropH > srcH {
+		cropW, cropH = srcH*v.Width/v.Height, srcH
+	}
+	x := b.Min.X + (srcW-cropW)/2
+	y := b.Min.Y + (srcH-cropH)/2
+
+	width, height := min(v.Width, cropW), min(v.Height, cropH)
+	if width*v.Height != height*v.Width {
+		// Keep the aspect ratio when the crop is smaller than the variant.
+		height = width * v.Height / v.Width
+	}
+
+	return image.Rect(x, y, x+cropW, y+cropH), max(width, 1), max(height, 1)
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// countTimeout limits counting enrollments on scrape.
+const countTimeout = 5 * time.Second
+
+// enrollmentsCollector struct to describe the enrollments business metric.
+// Enrollments are written by other services, so they are counted in the database on scrape.
+type enrollmentsCollector struct {
+	count func(ctx context.Context) (total, completed int64, err error)
+	desc  *prometheus.Desc
+}
+
+// RegisterEnrollments func for exporting the number of enrollments by status, counted by count.
+func RegisterEnrollments(count func(ctx context.Context) (total, completed int64, err error)) {
+	Registry.MustRegister(&enrollmentsCollector{
+		count: count,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "enrollments"),
+			"Enrollments in courses by status: active or completed.", []string{"status"}, nil),
+	})
+}
+
+// Describe method for sending the description of the enrollments metric.
+func (c *enrollmentsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect method for sending the current number of enrollments.
+// Nothing is sent, if they cannot be counted, so the other metrics are still scraped.
+func (c *enrollmentsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), countTimeout)
+	defer cancel()
+
+	total, completed, err := c.count(ctx)
+	if err != nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(total-completed), "active")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(completed), "completed")
+}
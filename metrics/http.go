@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"opendavinci/problem"
+)
+
+// unmatchedRoute labels requests of no route, so scanners cannot grow label values.
+const unmatchedRoute = "unmatched"
+
+// Middleware func for counting requests and their latency by route template.
+func Middleware(c *fiber.Ctx) error {
+	start := time.Now()
+	HTTPInFlight.Inc()
+	defer HTTPInFlight.Dec()
+
+	err := c.Next()
+
+	// Errors are turned into responses by the error handler after the middleware.
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = problem.From(err).Status
+	}
+
+	route := c.Route().Path
+	if status == fiber.StatusNotFound && route == "/" {
+		route = unmatchedRoute // the not found handler is mounted at /
+	}
+
+	labels := []string{c.Method(), route, strconv.Itoa(status)}
+	HTTPRequests.WithLabelValues(labels...).Inc()
+	HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+// Handler func for serving metrics in Prometheus text format.
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// NewServer func for serving metrics on a separate admin address, e.g. :9090.
+func NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace prefixes all metrics of the application.
+const namespace = "opendavinci"
+
+// Registry keeps all metrics served at /metrics.
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics, route is the template like /api/v1/course/:id, not the URL.
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests in progress.",
+	})
+)
+
+// JWTFailures counts rejected tokens by reason: missing, malformed, expired or invalid.
+var JWTFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "jwt_failures_total",
+	Help:      "Rejected JWT access tokens by reason.",
+}, []string{"reason"})
+
+// RateLimited counts requests rejected by policy: anonymous, user or token,
+// RateLimitErrors counts requests let through because the store failed.
+var (
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by rate limit policy.",
+	}, []string{"policy"})
+
+	RateLimitErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_errors_total",
+		Help:      "Requests allowed without rate limit because the store failed, by policy.",
+	}, []string{"policy"})
+)
+
+// EventSubscribers is the number of open change feed streams,
+// EventsDropped counts streams ended because they lagged behind.
+var (
+	EventSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "event_subscribers",
+		Help:      "Open streams of the change feed.",
+	})
+
+	EventsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "event_subscribers_dropped_total",
+		Help:      "Change feed streams ended because they did not keep up.",
+	})
+)
+
+// WebhookAttempts counts attempts of webhook deliveries by outcome:
+// delivered, retried or failed, after the last attempt.
+var WebhookAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "webhook_attempts_total",
+	Help:      "Attempts of webhook deliveries by outcome: delivered, retried or failed.",
+}, []string{"outcome"})
+
+// JobsProcessed counts attempts of background jobs by kind and outcome: done, retried or dead,
+// JobDuration observes how long their handlers ran.
+var (
+	JobsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_processed_total",
+		Help:      "Attempts of background jobs by kind and outcome: done, retried or dead.",
+	}, []string{"kind", "outcome"})
+
+	JobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Run time of background job attempts by kind.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind"})
+)
+
+// Business metrics.
+var (
+	CoursesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "courses_created_total",
+		Help:      "Courses created with the API.",
+	})
+
+	CoursesUpdated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "courses_updated_total",
+		Help:      "Courses updated with the API.",
+	})
+
+	CatalogImports = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "catalog_imported_courses_total",
+		Help:      "Courses imported from catalogs and packages by action: created, updated or failed.",
+	}, []string{"action"})
+
+	MediaUploads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "media_uploads_total",
+		Help:      "Stored uploads by kind: image or attachment.",
+	}, []string{"kind"})
+
+	MediaUploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "media_upload_bytes_total",
+		Help:      "Size of stored uploads by kind: image or attachment.",
+	}, []string{"kind"})
+
+	TokensIssued = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "access_tokens_issued_total",
+		Help:      "Access tokens issued.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests, HTTPDuration, HTTPInFlight,
+		JWTFailures,
+		RateLimited, RateLimitErrors,
+		EventSubscribers, EventsDropped,
+		WebhookAttempts,
+		JobsProcessed, JobDuration,
+		CoursesCreated, CoursesUpdated, CatalogImports, MediaUploads, MediaUploadBytes, TokensIssued,
+	)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry struct to describe one entry of the append-only audit log.
+// Updates keep only the changed fields in before and after, creates have no before
+// and deletes no after. Secrets are recorded as "[redacted]".
+type AuditEntry struct {
+	ID           int64           `db:"id" json:"id"`
+	Created      time.Time       `db:"created" json:"created"`
+	Actor        string          `db:"actor" json:"actor"`   // user:<id>, token:<hash>, anonymous or system
+	Action       string          `db:"action" json:"action"` // e.g. course.updated or auth.token_issued
+	ResourceType string          `db:"resourcetype" json:"resourceType"`
+	ResourceID   string          `db:"resourceid" json:"resourceId"`
+	Before       json.RawMessage `db:"before" json:"before"`
+	After        json.RawMessage `db:"after" json:"after"`
+	RequestID    string          `db:"requestid" json:"requestId"`
+	IP           string          `db:"ip" json:"ip"`
+	PrevHash     *string         `db:"prevhash" json:"prevHash,omitempty"` // set with AUDIT_HASH_CHAIN
+	Hash         *string         `db:"hash" json:"hash,omitempty"`
+}
+
+// AuditFilter struct to describe a query of the audit log, empty fields match all entries.
+type AuditFilter struct {
+	Actor        string
+	ResourceType string
+	ResourceID   string
+	From         *time.Time // inclusive
+	To           *time.Time // exclusive
+	BeforeID     int64      // entries older than this one, the cursor of the next page
+	Limit        int
+}
@@ -0,0 +1,17 @@
+package models
+
+// CatalogCourse struct to describe one course with its lessons in catalog import and export.
+type CatalogCourse struct {
+	Course
+	Lessons []Lesson `json:"lessons"`
+}
+
+// ImportResult struct to describe the outcome of importing one catalog row.
+type ImportResult struct {
+	Row            string `json:"row"`
+	CourseID       string `json:"courseId,omitempty"`
+	Action         string `json:"action,omitempty"` // created or updated
+	LessonsCreated int    `json:"lessonsCreated"`
+	LessonsUpdated int    `json:"lessonsUpdated"`
+	Error          string `json:"error,omitempty"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Enrollment struct to describe a user enrolled in a course.
+type Enrollment struct {
+	ID        uuid.UUID  `db:"id" json:"id"`
+	Created   time.Time  `db:"created" json:"created"`
+	UserID    uuid.UUID  `db:"userid" json:"userId"`
+	CourseID  uuid.UUID  `db:"courseid" json:"courseId"`
+	Progress  int        `db:"progress" json:"progress"`   // percent of the course done
+	Completed *time.Time `db:"completed" json:"completed"` // nil, until the course is done
+}
+
+// EnrollmentCounts struct to describe the number of enrollments, e.g. for metrics.
+type EnrollmentCounts struct {
+	Total     int64 `db:"total"`
+	Completed int64 `db:"completed"`
+}
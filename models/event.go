@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event struct to describe a change of a course or lesson, recorded by triggers of their tables.
+type Event struct {
+	ID         int64     `db:"id" json:"id"`
+	Created    time.Time `db:"created" json:"created"`
+	Type       string    `db:"type" json:"type"`     // course or lesson
+	Action     string    `db:"action" json:"action"` // created, updated or deleted
+	ResourceID uuid.UUID `db:"resourceid" json:"resourceId"`
+	TxID       int64     `db:"txid" json:"-"` // transaction of the change, orders the feed
+}
+
+// After method for checking, if e comes after o in the feed, ordered by transaction and ID.
+func (e Event) After(o Event) bool {
+	if e.TxID != o.TxID {
+		return e.TxID > o.TxID
+	}
+
+	return e.ID > o.ID
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// IdempotencyKey struct to describe the first response to a POST request with an Idempotency-Key header.
+// Status is 0 while the first request is in progress.
+type IdempotencyKey struct {
+	UserKey     string    `db:"userkey"` // user or access token of the request
+	Key         string    `db:"key"`
+	Fingerprint string    `db:"fingerprint"` // of method, path and body
+	Status      int       `db:"status"`
+	Headers     Headers   `db:"headers"`
+	Body        []byte    `db:"body"`
+	Created     time.Time `db:"created"`
+	LockedUntil time.Time `db:"lockeduntil"` // a retry takes over the key after it, if the first request is still in progress
+	Expires     time.Time `db:"expires"`
+}
+
+// Headers type to describe the JSON object of response headers.
+type Headers map[string]string
+
+// Value method for storing headers as JSON.
+func (h Headers) Value() (driver.Value, error) {
+	return json.Marshal(h)
+}
+
+// Scan method for reading headers from a JSON column.
+func (h *Headers) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*h = Headers{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return fmt.Errorf("cannot scan %T into Headers", value)
+	}
+}
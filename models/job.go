@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job statuses.
+const (
+	JobPending = "pending" // waiting for runAt
+	JobRunning = "running" // claimed by a worker until lockedUntil
+	JobDone    = "done"    // the handler succeeded
+	JobDead    = "dead"    // all attempts failed, kept until it is retried by an admin
+)
+
+// Job struct to describe a background job of a kind, with the arguments of its handler.
+type Job struct {
+	ID          int64           `db:"id" json:"id"`
+	Created     time.Time       `db:"created" json:"created"`
+	Kind        string          `db:"kind" json:"kind"`
+	Args        json.RawMessage `db:"args" json:"args"`
+	UniqueKey   *string         `db:"uniquekey" json:"uniqueKey"` // one pending or running job per kind and key
+	Status      string          `db:"status" json:"status"`
+	Attempts    int             `db:"attempts" json:"attempts"`
+	RunAt       time.Time       `db:"runat" json:"runAt"`
+	LockedUntil *time.Time      `db:"lockeduntil" json:"lockedUntil"`
+	LockedBy    *string         `db:"lockedby" json:"lockedBy"`
+	LastError   string          `db:"lasterror" json:"lastError"`
+	Finished    *time.Time      `db:"finished" json:"finished"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lesson struct to describe lesson object.
+type Lesson struct {
+	ID          uuid.UUID   `db:"id" json:"id" validate:"required,uuid"`
+	Created     time.Time   `db:"created" json:"created"`
+	LessonID    string      `db:"lessonid" json:"lessonId" validate:"required"`
+	CourseID    string      `db:"courseid" json:"courseId"`
+	Position    int         `db:"position" json:"position"`
+	Title       string      `db:"title" json:"title" validate:"required,lte=255"`
+	Content     string      `db:"content" json:"content"`
+	Format      string      `db:"format" json:"format" validate:"omitempty,oneof=markdown html text"`
+	ResourceURL string      `db:"resourceurl" json:"resourceUrl" validate:"lte=255"`
+	Attachments Attachments `db:"attachments" json:"attachments"`
+}
+
+// Attachment struct to describe a file uploaded for a lesson.
+type Attachment struct {
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Uploaded    time.Time `json:"uploaded"`
+	URL         string    `json:"url,omitempty"`
+}
+
+// Attachments type to describe the JSON list of lesson attachments.
+type Attachments []Attachment
+
+// Value method for storing attachments as JSON.
+func (a Attachments) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan method for reading attachments from a JSON column.
+func (a *Attachments) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = Attachments{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("cannot scan %T into Attachments", value)
+	}
+}
@@ -0,0 +1,134 @@
+package models
+
+import "opendavinci/render"
+
+// Responses of the v1 API. Every response has the error flag and msg,
+// the result is sent in a key named after it.
+
+// CoursesResponse struct to describe the list of courses.
+type CoursesResponse struct {
+	Error   bool     `json:"error"`
+	Msg     *string  `json:"msg"`
+	Count   int      `json:"count"`
+	Courses []Course `json:"courses"`
+
+	NextCursor string `json:"nextCursor,omitempty"` // cursor of the next page, if there is one
+}
+
+// CourseResponse struct to describe one course.
+type CourseResponse struct {
+	Error  bool    `json:"error"`
+	Msg    *string `json:"msg"`
+	Course Course  `json:"course"`
+}
+
+// LessonResponse struct to describe one lesson.
+type LessonResponse struct {
+	Error  bool    `json:"error"`
+	Msg    *string `json:"msg"`
+	Lesson Lesson  `json:"lesson"`
+}
+
+// DocumentResponse struct to describe lesson content rendered to HTML.
+type DocumentResponse struct {
+	Error    bool            `json:"error"`
+	Msg      *string         `json:"msg"`
+	Document render.Document `json:"document"`
+}
+
+// AttachmentResponse struct to describe an uploaded lesson attachment.
+type AttachmentResponse struct {
+	Error      bool       `json:"error"`
+	Msg        *string    `json:"msg"`
+	Attachment Attachment `json:"attachment"`
+}
+
+// UserResponse struct to describe one user.
+type UserResponse struct {
+	Error bool    `json:"error"`
+	Msg   *string `json:"msg"`
+	User  User    `json:"user"`
+}
+
+// TokenResponse struct to describe a new access token.
+type TokenResponse struct {
+	Error       bool    `json:"error"`
+	Msg         *string `json:"msg"`
+	AccessToken string  `json:"access_token"`
+}
+
+// ImportResponse struct to describe the outcome of a catalog import.
+type ImportResponse struct {
+	Error   bool            `json:"error"`
+	Msg     *string         `json:"msg"`
+	DryRun  bool            `json:"dryRun"`
+	Count   int             `json:"count"`
+	Failed  int             `json:"failed"`
+	Results []*ImportResult `json:"results"`
+}
+
+// WebhooksResponse struct to describe the list of webhooks, without secrets.
+type WebhooksResponse struct {
+	Error    bool      `json:"error"`
+	Msg      *string   `json:"msg"`
+	Count    int       `json:"count"`
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// WebhookResponse struct to describe one webhook, the secret is sent only when it is created.
+type WebhookResponse struct {
+	Error   bool    `json:"error"`
+	Msg     *string `json:"msg"`
+	Webhook Webhook `json:"webhook"`
+}
+
+// DeliveriesResponse struct to describe the delivery history of a webhook, newest first.
+type DeliveriesResponse struct {
+	Error      bool       `json:"error"`
+	Msg        *string    `json:"msg"`
+	Count      int        `json:"count"`
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+// DeliveryResponse struct to describe one delivery with its attempts.
+type DeliveryResponse struct {
+	Error    bool              `json:"error"`
+	Msg      *string           `json:"msg"`
+	Delivery Delivery          `json:"delivery"`
+	Attempts []DeliveryAttempt `json:"attempts,omitempty"` // not sent for new deliveries
+}
+
+// JobsResponse struct to describe a list of background jobs, newest first.
+type JobsResponse struct {
+	Error bool    `json:"error"`
+	Msg   *string `json:"msg"`
+	Count int     `json:"count"`
+	Jobs  []Job   `json:"jobs"`
+}
+
+// JobResponse struct to describe one background job.
+type JobResponse struct {
+	Error bool    `json:"error"`
+	Msg   *string `json:"msg"`
+	Job   Job     `json:"job"`
+}
+
+// AuditLogResponse struct to describe a page of the audit log, newest first.
+type AuditLogResponse struct {
+	Error      bool         `json:"error"`
+	Msg        *string      `json:"msg"`
+	Count      int          `json:"count"`
+	Entries    []AuditEntry `json:"entries"`
+	NextCursor string       `json:"nextCursor,omitempty"` // cursor of the next page, if there is one
+}
+
+// AuditVerifyResponse struct to describe the outcome of verifying the hash chain of the audit log.
+type AuditVerifyResponse struct {
+	Error    bool    `json:"error"`
+	Msg      *string `json:"msg"`
+	Valid    bool    `json:"valid"`
+	Checked  int     `json:"checked"`            // chained entries verified
+	Head     string  `json:"head"`               // hash of the last verified entry, keep it to detect rewrites of the whole chain
+	BrokenAt int64   `json:"brokenAt,omitempty"` // ID of the first entry which failed
+	Reason   string  `json:"reason,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleAdmin is the rbacrole of users who may call the admin routes.
+const RoleAdmin = "admin"
+
+// User struct to describe user object.
+type User struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Created time.Time `db:"created" json:"created"`
+	Email   string    `db:"email" json:"email"`
+	Role    string    `db:"rbacrole" json:"role"`
+}
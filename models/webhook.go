@@ -0,0 +1,77 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook struct to describe a subscription of a partner system to events.
+type Webhook struct {
+	ID      uuid.UUID  `db:"id" json:"id"`
+	Created time.Time  `db:"created" json:"created"`
+	URL     string     `db:"url" json:"url" validate:"required,http_url,lte=2048"`
+	Events  EventTypes `db:"events" json:"events" validate:"required,min=1,dive,oneof=course.published enrollment.completed"`
+	Secret  string     `db:"secret" json:"secret,omitempty" validate:"omitempty,min=16,lte=255"` // sent only when the webhook is created
+	Active  bool       `db:"active" json:"active"`
+}
+
+// EventTypes type to describe the JSON list of event types of a webhook.
+type EventTypes []string
+
+// Value method for storing event types as JSON.
+func (e EventTypes) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+// Scan method for reading event types from a JSON column.
+func (e *EventTypes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*e = EventTypes{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into EventTypes", value)
+	}
+}
+
+// Delivery statuses.
+const (
+	DeliveryPending   = "pending"   // waiting for the next attempt
+	DeliveryDelivered = "delivered" // the receiver answered with 2xx
+	DeliveryFailed    = "failed"    // all attempts failed
+)
+
+// Delivery struct to describe one event sent to one webhook, with the outcome of its last attempt.
+type Delivery struct {
+	ID             uuid.UUID       `db:"id" json:"id"`
+	Created        time.Time       `db:"created" json:"created"`
+	WebhookID      uuid.UUID       `db:"webhookid" json:"webhookId"`
+	EventID        uuid.UUID       `db:"eventid" json:"eventId"` // the same for redeliveries of the event
+	Event          string          `db:"event" json:"event"`
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Redelivery     bool            `db:"redelivery" json:"redelivery"`
+	Status         string          `db:"status" json:"status"`
+	Attempts       int             `db:"attempts" json:"attempts"`
+	NextAttempt    *time.Time      `db:"nextattempt" json:"nextAttempt"`       // nil, once delivered or failed
+	ResponseStatus int             `db:"responsestatus" json:"responseStatus"` // 0, if there was no response
+	Error          string          `db:"error" json:"error"`
+}
+
+// DeliveryAttempt struct to describe one request sent for a delivery.
+type DeliveryAttempt struct {
+	ID             int64     `db:"id" json:"id"`
+	Created        time.Time `db:"created" json:"created"`
+	DeliveryID     uuid.UUID `db:"deliveryid" json:"deliveryId"`
+	ResponseStatus int       `db:"responsestatus" json:"responseStatus"`
+	ResponseBody   string    `db:"responsebody" json:"responseBody"` // cut to the first KB
+	Error          string    `db:"error" json:"error"`
+	DurationMS     int64     `db:"durationms" json:"durationMs"`
+}
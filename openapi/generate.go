@@ -0,0 +1,234 @@
+package openapi
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Config struct to describe what is generated besides the operations of handlers.
+type Config struct {
+	Info        Info
+	Tags        []Tag
+	Error       any    // model of error responses, described as default response
+	ErrorType   string // content type of error responses
+	Security    map[string]*SecurityScheme
+	Middlewares []Middleware
+}
+
+// Middleware struct to describe what a middleware adds to operations of routes using it.
+type Middleware struct {
+	Name      string // package qualified name or prefix of the handler, e.g. opendavinci/routes.RateLimit
+	Security  string // name of the required security scheme
+	Responses []Response
+}
+
+// Generate func for creating the document of routes, e.g. app.GetRoutes(true).
+// Paths and methods come from routes, operations are declared with Describe,
+// schemas are generated from models with their json and validate tags.
+// Routes of handlers without a declared operation, e.g. docs and metrics, are left out.
+func Generate(cfg Config, routes []fiber.Route) *Document {
+	s := newSchemas()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    cfg.Info,
+		Paths:   map[string]PathItem{},
+		Tags:    cfg.Tags,
+		Components: Components{
+			Schemas:         s.components,
+			SecuritySchemes: cfg.Security,
+		},
+	}
+
+	ids := map[string]int{}
+	for _, r := range routes {
+		if r.Method == fiber.MethodHead || len(r.Handlers) == 0 {
+			continue
+		}
+
+		handler := r.Handlers[len(r.Handlers)-1]
+		op, ok := lookup(handler)
+		if !ok {
+			continue
+		}
+		if op.ID == "" {
+			name := handlerName(handler)
+			op.ID = name[strings.LastIndex(name, ".")+1:]
+		}
+		if ids[op.ID]++; ids[op.ID] > 1 {
+			op.ID += strconv.Itoa(ids[op.ID])
+		}
+
+		path, obj := operation(s, cfg, r, op)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(r.Method)] = obj
+		doc.routes = append(doc.routes, route{method: r.Method, path: r.Path, op: obj})
+	}
+
+	return doc
+}
+
+// operation func for describing route r with the declared op.
+func operation(s *schemas, cfg Config, r fiber.Route, op Operation) (string, *OperationObject) {
+	obj := &OperationObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+		Responses:   map[string]*ResponseObject{},
+	}
+
+	// Path parameters are named by the route, wildcard takes the name of a declared one.
+	declared := map[string]Param{}
+	wildcard := "path"
+	for _, p := range op.Params {
+		declared[p.In+":"+p.Name] = p
+		if p.In == "path" && !slices.Contains(r.Params, p.Name) {
+			wildcard = p.Name
+		}
+	}
+
+	segments := strings.Split(r.Path, "/")
+	for i, seg := range segments {
+		name := ""
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			name = strings.TrimRight(seg[1:], "?")
+		case seg == "*" || seg == "+":
+			name = wildcard
+		default:
+			continue
+		}
+		segments[i] = "{" + name + "}"
+
+		p, ok := declared["path:"+name]
+		if !ok {
+			p = Path(name, "", "")
+		}
+		obj.Parameters = append(obj.Parameters, parameter(s, p))
+	}
+	for _, p := range op.Params {
+		if p.In != "path" {
+			obj.Parameters = append(obj.Parameters, parameter(s, p))
+		}
+	}
+
+	if op.Body != nil {
+		obj.RequestBody = &RequestBodyObject{
+			Description: op.Body.Description,
+			Required:    true,
+			Content:     content(op.Body.ContentType, body(s, op.Body)),
+		}
+	}
+
+	responses := op.Responses
+	if len(responses) == 0 {
+		responses = []Response{{Status: http.StatusOK}}
+	}
+	for _, m := range cfg.Middlewares {
+		if !uses(r, m.Name) {
+			continue
+		}
+		if m.Security != "" {
+			obj.Security = append(obj.Security, map[string][]string{m.Security: {}})
+		}
+		responses = append(responses, m.Responses...)
+	}
+	for _, resp := range responses {
+		obj.Responses[strconv.Itoa(resp.Status)] = response(s, resp)
+	}
+	if cfg.Error != nil {
+		obj.Responses["default"] = response(s, Response{
+			Description: "Error",
+			ContentType: cfg.ErrorType,
+			Model:       cfg.Error,
+		})
+	}
+
+	return strings.Join(segments, "/"), obj
+}
+
+func parameter(s *schemas, p Param) *ParameterObject {
+	schema := &Schema{Type: "string"}
+	if p.Type != nil {
+		schema = s.of(p.Type)
+	}
+	for _, v := range p.Enum {
+		schema.Enum = append(schema.Enum, v)
+	}
+
+	return &ParameterObject{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required || p.In == "path",
+		Schema:      schema,
+	}
+}
+
+// body func for getting the schema of the request body, limited to the sent properties.
+// Properties of models are only required, if they are validated as required.
+func body(s *schemas, b *Body) *Schema {
+	schema := s.of(b.Model)
+	full := s.resolve(schema)
+	if full.Properties == nil || full.Ref != "" || (schema.Ref == "" && len(b.Only) == 0 && len(b.Omit) == 0) {
+		return schema
+	}
+
+	limited := &Schema{Type: full.Type, Properties: map[string]*Schema{}}
+	for name, prop := range full.Properties {
+		if (len(b.Only) > 0 && !slices.Contains(b.Only, name)) || slices.Contains(b.Omit, name) {
+			continue
+		}
+		limited.Properties[name] = prop
+		if slices.Contains(full.input, name) {
+			limited.Required = append(limited.Required, name)
+		}
+	}
+	slices.Sort(limited.Required)
+
+	return limited
+}
+
+func response(s *schemas, r Response) *ResponseObject {
+	obj := &ResponseObject{Description: r.Description}
+	if obj.Description == "" {
+		obj.Description = http.StatusText(r.Status)
+	}
+	if r.Model != nil {
+		obj.Content = content(r.ContentType, s.of(r.Model))
+	}
+	for _, name := range r.Headers {
+		if obj.Headers == nil {
+			obj.Headers = map[string]*Header{}
+		}
+		obj.Headers[name] = &Header{Schema: &Schema{Type: "string"}}
+	}
+
+	return obj
+}
+
+func content(contentType string, schema *Schema) map[string]*MediaType {
+	if contentType == "" {
+		contentType = fiber.MIMEApplicationJSON
+	}
+
+	return map[string]*MediaType{contentType: {Schema: schema}}
+}
+
+// uses func for checking, if a handler of route r has the name or its prefix.
+func uses(r fiber.Route, name string) bool {
+	for _, h := range r.Handlers {
+		if strings.HasPrefix(handlerName(h), name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Operation struct to describe what a handler does, its route comes from the app.
+type Operation struct {
+	ID          string // operationId, default is the handler name
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	Params      []Param
+	Body        *Body
+	Responses   []Response
+}
+
+// Param struct to describe a path, query or header parameter.
+// Type is a Go value of the parameter type, e.g. uuid.UUID{} or 0, default is string.
+type Param struct {
+	Name        string
+	In          string // path, query or header
+	Description string
+	Required    bool
+	Type        any
+	Enum        []string
+}
+
+// Body struct to describe the request body.
+// Model is a Go value of the body, or a *Schema for other bodies.
+type Body struct {
+	ContentType string // default is application/json
+	Description string
+	Model       any
+	Only        []string // properties of the model sent in the request, default is all
+	Omit        []string // properties of the model set by the server
+}
+
+// Response struct to describe a response, Model is nil for responses without body.
+type Response struct {
+	Status      int
+	Description string
+	ContentType string // default is application/json
+	Model       any
+	Headers     []string // names of response headers
+}
+
+// Path func for describing a path parameter.
+func Path(name, description string, typ any) Param {
+	return Param{Name: name, In: "path", Description: description, Required: true, Type: typ}
+}
+
+// Query func for describing an optional query parameter.
+func Query(name, description string, typ any) Param {
+	return Param{Name: name, In: "query", Description: description, Type: typ}
+}
+
+// File func for describing a multipart body with a file in field.
+func File(field, description string) *Body {
+	return &Body{
+		ContentType: fiber.MIMEMultipartForm,
+		Description: description,
+		Model: &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{field: {Type: "string", ContentMediaType: "application/octet-stream"}},
+			Required:   []string{field},
+		},
+	}
+}
+
+var (
+	mu         sync.RWMutex
+	operations = map[string]Operation{}
+)
+
+// Describe func for declaring the operation of handler, e.g. in init of controllers.
+func Describe(handler fiber.Handler, op Operation) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	operations[handlerName(handler)] = op
+}
+
+func lookup(handler fiber.Handler) (Operation, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	op, ok := operations[handlerName(handler)]
+	return op, ok
+}
+
+// handlerName func for getting the package qualified name of handler,
+// e.g. opendavinci/controllers.GetCourse.
+func handlerName(handler fiber.Handler) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return ""
+	}
+
+	return fn.Name()
+}
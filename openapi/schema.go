@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// refPrefix locates schemas of named structs in components.
+const refPrefix = "#/components/schemas/"
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemas struct to describe schemas of Go types collected for components.
+// Named structs become components and are referenced, others are inlined.
+type schemas struct {
+	components map[string]*Schema
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}}
+}
+
+// of method for getting the schema of the type of v, nil v has an empty schema.
+func (s *schemas) of(v any) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	if schema, ok := v.(*Schema); ok {
+		return schema
+	}
+
+	return s.typeSchema(reflect.TypeOf(v))
+}
+
+func (s *schemas) typeSchema(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t, nullable = t.Elem(), true
+	}
+
+	schema := s.valueSchema(t)
+	if nullable && schema.Ref == "" {
+		schema.Type = []any{schema.Type, "null"}
+	}
+
+	return schema
+}
+
+func (s *schemas) valueSchema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentMediaType: "application/octet-stream"}
+		}
+		return &Schema{Type: "array", Items: s.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		if _, ok := s.components[t.Name()]; !ok {
+			s.components[t.Name()] = &Schema{} // placeholder for recursive types
+			s.components[t.Name()] = s.structSchema(t)
+		}
+		return &Schema{Ref: refPrefix + t.Name()}
+	}
+
+	// Interfaces and other kinds may hold any value.
+	return &Schema{}
+}
+
+// structSchema describes exported fields by their json names,
+// fields of embedded structs are inlined like encoding/json does.
+// Fields without omitempty are always sent, so they are required in responses,
+// requests only need the fields with the required validate tag.
+func (s *schemas) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded := s.structSchema(f.Type)
+			for key, prop := range embedded.Properties {
+				schema.Properties[key] = prop
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			schema.input = append(schema.input, embedded.input...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := s.typeSchema(f.Type)
+		if validations(prop, f.Tag.Get("validate")) {
+			schema.input = append(schema.input, name)
+		}
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+	slices.Sort(schema.Required)
+	slices.Sort(schema.input)
+
+	return schema
+}
+
+// resolve method for getting the schema a reference points to.
+func (s *schemas) resolve(schema *Schema) *Schema {
+	if name, ok := strings.CutPrefix(schema.Ref, refPrefix); ok {
+		if c, ok := s.components[name]; ok {
+			return c
+		}
+	}
+
+	return schema
+}
+
+// validations func for adding keywords of validator tags to schema,
+// it reports if the field is required. Referenced schemas are not changed.
+func validations(schema *Schema, tag string) bool {
+	required := false
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			// Rules after dive are the ones of the items.
+			if schema.Items != nil {
+				validations(schema.Items, strings.Join(rules[i+1:], ","))
+			}
+			break
+		}
+		if name == "required" {
+			required = true
+			continue
+		}
+		if schema.Ref != "" {
+			continue
+		}
+
+		switch name {
+		case "uuid":
+			schema.Format = "uuid"
+		case "email":
+			schema.Format = "email"
+		case "url", "http_url":
+			schema.Format = "uri"
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, v)
+			}
+		case "lte", "max":
+			setBound(schema, param, false)
+		case "gte", "min":
+			setBound(schema, param, true)
+		}
+	}
+
+	return required
+}
+
+// setBound sets length of strings or value of numbers.
+func setBound(schema *Schema, param string, lower bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string":
+		length := int(n)
+		if lower {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Errors type to describe what does not match the document,
+// keys are JSON pointers of the locations, e.g. /body/title or /query/format.
+type Errors map[string]string
+
+// Error method for listing the locations, one error per location.
+func (e Errors) Error() string {
+	locations := make([]string, 0, len(e))
+	for loc, msg := range e {
+		locations = append(locations, loc+": "+msg)
+	}
+	sort.Strings(locations)
+
+	return strings.Join(locations, "; ")
+}
+
+// Locations method for getting the sorted locations of errors.
+func (e Errors) Locations() []string {
+	locations := make([]string, 0, len(e))
+	for loc := range e {
+		locations = append(locations, loc)
+	}
+	sort.Strings(locations)
+
+	return locations
+}
+
+// Request struct to describe the parts of a request checked by the validator.
+type Request struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	ContentType string
+	Body        []byte
+}
+
+// Validator struct to describe how requests and responses are checked against a document.
+type Validator struct {
+	doc *Document
+}
+
+// NewValidator func for checking requests and responses against doc made by Generate.
+func NewValidator(doc *Document) *Validator {
+	return &Validator{doc: doc}
+}
+
+// Find method for getting the operation of a request and its path parameters.
+// Routes are matched like Fiber does, in the order they were registered.
+func (v *Validator) Find(method, path string) (*OperationObject, map[string]string, bool) {
+	for _, r := range v.doc.routes {
+		if r.method != method {
+			continue
+		}
+		params, ok := match(r.path, path)
+		if !ok {
+			continue
+		}
+
+		// Wildcard takes the name of the path parameter missing in the template.
+		for _, p := range r.op.Parameters {
+			if _, ok := params[p.Name]; p.In == "path" && !ok {
+				params[p.Name] = params["*"] + params["+"]
+			}
+		}
+
+		return r.op, params, true
+	}
+
+	return nil, nil, false
+}
+
+// ValidateRequest method for checking path and query parameters and a JSON body of op.
+// Bodies of other content types, e.g. multipart uploads, are checked by handlers.
+func (v *Validator) ValidateRequest(op *OperationObject, params map[string]string, req Request) Errors {
+	errs := Errors{}
+
+	for _, p := range op.Parameters {
+		loc := "/" + p.In + "/" + escape(p.Name)
+		switch p.In {
+		case "path":
+			v.validateParam(p.Schema, params[p.Name], loc, errs)
+		case "query":
+			if !req.Query.Has(p.Name) {
+				if p.Required {
+					errs[loc] = "is required"
+				}
+				continue
+			}
+			v.validateParam(p.Schema, req.Query.Get(p.Name), loc, errs)
+		}
+	}
+
+	if op.RequestBody != nil {
+		media, ok := op.RequestBody.Content["application/json"]
+		switch {
+		case len(req.Body) == 0 && op.RequestBody.Required:
+			errs["/body"] = "is required"
+		case ok && isJSON(req.ContentType):
+			v.validateJSON(media.Schema, req.Body, "/body", errs)
+		}
+	}
+
+	return errs
+}
+
+// ValidateResponse method for checking a JSON response of op against the document.
+// Responses of undocumented statuses are checked against the default response.
+func (v *Validator) ValidateResponse(op *OperationObject, status int, contentType string, body []byte) Errors {
+	errs := Errors{}
+
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		if resp, ok = op.Responses["default"]; !ok {
+			errs["/response/status"] = "status " + strconv.Itoa(status) + " is not documented"
+			return errs
+		}
+	}
+	if len(resp.Content) == 0 {
+		if len(body) > 0 {
+			errs["/response/body"] = "must be empty"
+		}
+		return errs
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := resp.Content[mediaType]
+	if !ok {
+		errs["/response/contentType"] = "content type " + mediaType + " is not documented"
+		return errs
+	}
+	if isJSON(mediaType) {
+		v.validateJSON(media.Schema, body, "/response/body", errs)
+	}
+
+	return errs
+}
+
+// validateParam checks a parameter, it is parsed by the type of its schema.
+func (v *Validator) validateParam(schema *Schema, raw, loc string, errs Errors) {
+	schema = v.resolve(schema)
+
+	var value any = raw
+	switch schema.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			errs[loc] = "must be " + typeName(schema.Type)
+			return
+		}
+		value = json.Number(raw)
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs[loc] = "must be a boolean"
+			return
+		}
+		value = b
+	}
+
+	v.validateValue(schema, value, loc, errs)
+}
+
+func (v *Validator) validateJSON(schema *Schema, body []byte, loc string, errs Errors) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		errs[loc] = "must be valid JSON"
+		return
+	}
+
+	v.validateValue(schema, value, loc, errs)
+}
+
+// validateValue checks a decoded JSON value, locations of nested values are appended to loc.
+func (v *Validator) validateValue(schema *Schema, value any, loc string, errs Errors) {
+	schema = v.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	if !hasType(schema.Type, value) {
+		errs[loc] = "must be " + typeName(schema.Type)
+		return
+	}
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(value) }) {
+		errs[loc] = "must be one of " + enumList(schema.Enum)
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		if msg := checkString(schema, value); msg != "" {
+			errs[loc] = msg
+		}
+	case json.Number:
+		n, _ := value.Float64()
+		if schema.Minimum != nil && n < *schema.Minimum {
+			errs[loc] = "must be at least " + strconv.FormatFloat(*schema.Minimum, 'f', -1, 64)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			errs[loc] = "must be at most " + strconv.FormatFloat(*schema.Maximum, 'f', -1, 64)
+		}
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				errs[loc+"/"+escape(name)] = "is required"
+			}
+		}
+		for name, prop := range value {
+			if s, ok := schema.Properties[name]; ok {
+				v.validateValue(s, prop, loc+"/"+escape(name), errs)
+			} else if schema.AdditionalProperties != nil {
+				v.validateValue(schema.AdditionalProperties, prop, loc+"/"+escape(name), errs)
+			}
+		}
+	case []any:
+		if schema.Items != nil {
+			for i, item := range value {
+				v.validateValue(schema.Items, item, loc+"/"+strconv.Itoa(i), errs)
+			}
+		}
+	}
+}
+
+func (v *Validator) resolve(schema *Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(schema.Ref, refPrefix); ok {
+		return v.doc.Components.Schemas[name]
+	}
+
+	return schema
+}
+
+func checkString(schema *Schema, value string) string {
+	n := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && n < *schema.MinLength {
+		return "must be at least " + strconv.Itoa(*schema.MinLength) + " characters"
+	}
+	if schema.MaxLength != nil && n > *schema.MaxLength {
+		return "must be at most " + strconv.Itoa(*schema.MaxLength) + " characters"
+	}
+
+	var err error
+	switch schema.Format {
+	case "uuid":
+		_, err = uuid.Parse(value)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "email":
+		_, err = mail.ParseAddress(value)
+	case "uri":
+		var u *url.URL
+		if u, err = url.Parse(value); err == nil && !u.IsAbs() {
+			err = fmt.Errorf("not absolute")
+		}
+	}
+	if err != nil {
+		return "must be a valid " + schema.Format
+	}
+
+	return ""
+}
+
+// hasType reports, if value is of one of the types, no type allows any value.
+func hasType(types any, value any) bool {
+	names := []any{types}
+	if list, ok := types.([]any); ok {
+		names = list
+	}
+
+	for _, name := range names {
+		switch name {
+		case nil:
+			return true
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(json.Number); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(json.Number); ok {
+				if _, err := n.Int64(); err == nil {
+					return true
+				}
+			}
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func typeName(types any) string {
+	if list, ok := types.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, t := range list {
+			names = append(names, typeName(t))
+		}
+		return strings.Join(names, " or ")
+	}
+
+	switch types {
+	case "integer":
+		return "an integer"
+	case "object", "array":
+		return "an " + types.(string)
+	case "null":
+		return "null"
+	}
+
+	return fmt.Sprintf("a %v", types)
+}
+
+func enumList(enum []any) string {
+	values := make([]string, 0, len(enum))
+	for _, e := range enum {
+		values = append(values, fmt.Sprint(e))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// escape func for a token of JSON pointer, see RFC 6901.
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// match func for matching path with a Fiber route template,
+// it returns values of parameters, the wildcard is named by the document.
+func match(template, path string) (map[string]string, bool) {
+	tsegs := strings.Split(strings.Trim(template, "/"), "/")
+	psegs := strings.Split(strings.Trim(path, "/"), "/")
+	params := map[string]string{}
+
+	for i, seg := range tsegs {
+		switch {
+		case seg == "*" || seg == "+":
+			rest := ""
+			if i < len(psegs) {
+				rest = strings.Join(psegs[i:], "/")
+			}
+			if seg == "+" && rest == "" {
+				return nil, false
+			}
+			params[seg] = rest
+			return params, true
+		case i >= len(psegs):
+			if strings.HasPrefix(seg, ":") && strings.HasSuffix(seg, "?") && i == len(tsegs)-1 {
+				return params, true
+			}
+			return nil, false
+		case strings.HasPrefix(seg, ":"):
+			if psegs[i] == "" {
+				return nil, false
+			}
+			name := strings.TrimRight(seg[1:], "?")
+			value, err := url.PathUnescape(psegs[i])
+			if err != nil {
+				value = psegs[i]
+			}
+			params[name] = value
+		case !strings.EqualFold(seg, psegs[i]):
+			return nil, false
+		}
+	}
+
+	return params, len(tsegs) == len(psegs)
+}
@@ -0,0 +1,116 @@
+package problem
+
+// Stable error codes, clients may rely on them. Never change a published code.
+const (
+	CodeInternal            = "internal"
+	CodeUnavailable         = "service.unavailable"
+	CodeDatabaseUnavailable = "database.unavailable"
+	CodeTimeout             = "request.timeout"
+
+	CodeBadRequest           = "request.invalid"
+	CodeInvalidID            = "request.invalid_id"
+	CodeInvalidBody          = "request.invalid_body"
+	CodeInvalidQuery         = "request.invalid_query"
+	CodeTooLarge             = "request.too_large"
+	CodeUnsupportedMediaType = "request.unsupported_media_type"
+	CodeMethodNotAllowed     = "request.method_not_allowed"
+	CodeValidationFailed     = "validation.failed"
+
+	CodeUnauthorized = "auth.unauthorized"
+	CodeTokenMissing = "auth.token_missing"
+	CodeTokenInvalid = "auth.token_invalid"
+	CodeTokenExpired = "auth.token_expired"
+	CodeForbidden    = "auth.forbidden"
+
+	CodeNotFound      = "resource.not_found"
+	CodeConflict      = "resource.conflict"
+	CodeRouteNotFound = "route.not_found"
+	CodeRateLimited   = "rate_limit.exceeded"
+
+	CodeIdempotencyKeyInvalid = "idempotency.key_invalid"
+	CodeIdempotencyKeyReused  = "idempotency.key_reused"
+	CodeIdempotencyInProgress = "idempotency.in_progress"
+
+	CodeCourseNotFound   = "course.not_found"
+	CodeLessonNotFound   = "lesson.not_found"
+	CodeImageNotFound    = "media.image_not_found"
+	CodeVariantNotFound  = "media.variant_not_found"
+	CodeMediaNotFound    = "media.not_found"
+	CodeMediaInvalid     = "media.invalid"
+	CodeSignatureInvalid = "media.signature_invalid"
+	CodePackageInvalid   = "package.invalid"
+	CodeCatalogInvalid   = "catalog.invalid"
+
+	CodeGraphQLInvalid    = "graphql.invalid"
+	CodeGraphQLTooDeep    = "graphql.too_deep"
+	CodeGraphQLTooComplex = "graphql.too_complex"
+
+	CodeWebhookNotFound     = "webhook.not_found"
+	CodeDeliveryNotFound    = "webhook.delivery_not_found"
+	CodeWebhookURLForbidden = "webhook.url_forbidden"
+
+	CodeJobNotFound = "job.not_found"
+
+	CodeUserNotFound = "user.not_found"
+)
+
+// titles are short summaries of codes, the same for every occurrence.
+var titles = map[string]string{
+	CodeInternal:              "Internal server error",
+	CodeUnavailable:           "Service unavailable",
+	CodeDatabaseUnavailable:   "Database unavailable",
+	CodeTimeout:               "Request timed out",
+	CodeBadRequest:            "Invalid request",
+	CodeInvalidID:             "Invalid ID",
+	CodeInvalidBody:           "Invalid request body",
+	CodeInvalidQuery:          "Invalid query parameter",
+	CodeTooLarge:              "Request too large",
+	CodeUnsupportedMediaType:  "Unsupported media type",
+	CodeMethodNotAllowed:      "Method not allowed",
+	CodeValidationFailed:      "Validation failed",
+	CodeUnauthorized:          "Unauthorized",
+	CodeTokenMissing:          "Access token missing",
+	CodeTokenInvalid:          "Access token invalid",
+	CodeTokenExpired:          "Access token expired",
+	CodeForbidden:             "Forbidden",
+	CodeNotFound:              "Resource not found",
+	CodeConflict:              "Resource conflict",
+	CodeRouteNotFound:         "Endpoint not found",
+	CodeRateLimited:           "Too many requests",
+	CodeIdempotencyKeyInvalid: "Invalid idempotency key",
+	CodeIdempotencyKeyReused:  "Idempotency key reused",
+	CodeIdempotencyInProgress: "Request in progress",
+	CodeCourseNotFound:        "Course not found",
+	CodeLessonNotFound:        "Lesson not found",
+	CodeImageNotFound:         "Image not found",
+	CodeVariantNotFound:       "Image variant not found",
+	CodeMediaNotFound:         "Media not found",
+	CodeMediaInvalid:          "Invalid media",
+	CodeSignatureInvalid:      "Invalid media signature",
+	CodePackageInvalid:        "Invalid package",
+	CodeCatalogInvalid:        "Invalid catalog",
+	CodeGraphQLInvalid:        "Invalid GraphQL query",
+	CodeGraphQLTooDeep:        "GraphQL query too deep",
+	CodeGraphQLTooComplex:     "GraphQL query too complex",
+	CodeWebhookNotFound:       "Webhook not found",
+	CodeDeliveryNotFound:      "Webhook delivery not found",
+	CodeWebhookURLForbidden:   "Webhook URL not allowed",
+	CodeJobNotFound:           "Job not found",
+	CodeUserNotFound:          "User not found",
+}
+
+// statusCodes are codes of errors which only have an HTTP status, e.g. from Fiber.
+var statusCodes = map[int]string{
+	400: CodeBadRequest,
+	401: CodeUnauthorized,
+	403: CodeForbidden,
+	404: CodeNotFound,
+	405: CodeMethodNotAllowed,
+	408: CodeTimeout,
+	409: CodeConflict,
+	413: CodeTooLarge,
+	415: CodeUnsupportedMediaType,
+	429: CodeRateLimited,
+	503: CodeUnavailable,
+	504: CodeTimeout,
+}
@@ -0,0 +1,120 @@
+package problem
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	jwt "github.com/golang-jwt/jwt"
+	jwt4 "github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// From func for mapping any error to a problem.
+// Known errors of database, validator, JWT and JSON get their status and code,
+// all other errors are internal and their message is not sent.
+func From(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var (
+		fe        *fiber.Error
+		verrs     validator.ValidationErrors
+		jwtErr    *jwt.ValidationError
+		jwt4Err   *jwt4.ValidationError
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		pgErr     *pgconn.PgError
+		connErr   *pgconn.ConnectError
+	)
+
+	switch {
+	case errors.As(err, &fe):
+		code, ok := statusCodes[fe.Code]
+		if !ok {
+			code = CodeInternal
+		}
+		if fe.Code >= http.StatusInternalServerError {
+			return Wrap(err, fe.Code, code, "")
+		}
+		return Wrap(err, fe.Code, code, fe.Message)
+	case errors.Is(err, sql.ErrNoRows):
+		return Wrap(err, http.StatusNotFound, CodeNotFound, "resource is not found")
+	case errors.As(err, &verrs):
+		return Validation(verrs)
+	case errors.As(err, &jwtErr):
+		return tokenProblem(err, jwtErr.Errors&jwt.ValidationErrorExpired != 0)
+	case errors.As(err, &jwt4Err):
+		return tokenProblem(err, jwt4Err.Errors&jwt4.ValidationErrorExpired != 0)
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return InvalidBody(err)
+	case errors.As(err, &pgErr):
+		return databaseProblem(err, pgErr)
+	case errors.As(err, &connErr):
+		return Wrap(err, http.StatusServiceUnavailable, CodeDatabaseUnavailable, "database is not reachable")
+	case errors.Is(err, context.DeadlineExceeded):
+		return Wrap(err, http.StatusGatewayTimeout, CodeTimeout, "")
+	}
+
+	return Internal(err)
+}
+
+// Lookup func for mapping error of a query by ID: no rows is 404 with code and detail,
+// other errors are mapped by From.
+func Lookup(err error, code, detail string) *Problem {
+	if errors.Is(err, sql.ErrNoRows) {
+		return Wrap(err, http.StatusNotFound, code, detail)
+	}
+
+	return From(err)
+}
+
+// Validation func for 400 problem with message of each invalid field.
+func Validation(err error) *Problem {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return Internal(err)
+	}
+
+	p := Wrap(err, http.StatusBadRequest, CodeValidationFailed, "")
+	p.Errors = make(map[string]string, len(verrs))
+	fields := make([]string, 0, len(verrs))
+	for _, e := range verrs {
+		p.Errors[e.Field()] = e.Error()
+		fields = append(fields, e.Field())
+	}
+	sort.Strings(fields)
+	p.Detail = "invalid fields: " + strings.Join(fields, ", ")
+
+	return p
+}
+
+func tokenProblem(err error, expired bool) *Problem {
+	if expired {
+		return Wrap(err, http.StatusUnauthorized, CodeTokenExpired, "access token is expired")
+	}
+
+	return Wrap(err, http.StatusUnauthorized, CodeTokenInvalid, "access token is not valid")
+}
+
+// databaseProblem maps Postgres errors by SQLSTATE, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+func databaseProblem(err error, pgErr *pgconn.PgError) *Problem {
+	switch {
+	case pgErr.Code == "23505": // unique_violation
+		return Wrap(err, http.StatusConflict, CodeConflict, "resource already exists")
+	case strings.HasPrefix(pgErr.Code, "22"), strings.HasPrefix(pgErr.Code, "23"): // data exception, integrity constraint violation
+		return Wrap(err, http.StatusBadRequest, CodeBadRequest, "request conflicts with constraints of stored data")
+	case strings.HasPrefix(pgErr.Code, "08"), strings.HasPrefix(pgErr.Code, "53"), strings.HasPrefix(pgErr.Code, "57P"): // connection, resources, shutdown
+		return Wrap(err, http.StatusServiceUnavailable, CodeDatabaseUnavailable, "database is not available")
+	}
+
+	return Internal(err)
+}
@@ -0,0 +1,135 @@
+package problem
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"strconv"
+)
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// typePrefix makes codes URIs for the type member of RFC 7807.
+const typePrefix = "urn:opendavinci:problem:"
+
+// Problem struct to describe an error response of RFC 7807 (application/problem+json).
+// Handlers return it as error, the error handler of the app sends it.
+type Problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code"`
+	RequestID string            `json:"requestId,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"` // invalid fields of validation.failed
+
+	// Extensions are extra members of the problem object, e.g. unsupported package elements.
+	Extensions map[string]any `json:"-"`
+
+	cause error // logged, never sent
+}
+
+// New func for creating a problem with stable code and detail for the client.
+func New(status int, code, detail string) *Problem {
+	title, ok := titles[code]
+	if !ok {
+		title = http.StatusText(status)
+	}
+
+	return &Problem{
+		Type:   typePrefix + code,
+		Title:  title,
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Wrap func for creating a problem caused by err, err is logged but not sent.
+func Wrap(err error, status int, code, detail string) *Problem {
+	p := New(status, code, detail)
+	p.cause = err
+
+	return p
+}
+
+// With method for adding an extension member, standard members cannot be replaced.
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = map[string]any{}
+	}
+	p.Extensions[key] = value
+
+	return p
+}
+
+// MarshalJSON method for sending extension members next to the standard ones.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type plain Problem
+	data, err := json.Marshal((*plain)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := map[string]any{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	ext := maps.Clone(p.Extensions)
+	for key := range members {
+		delete(ext, key)
+	}
+	maps.Copy(members, ext)
+
+	return json.Marshal(members)
+}
+
+// Error method for describing the problem in logs, with its cause.
+func (p *Problem) Error() string {
+	msg := p.Code + " (" + strconv.Itoa(p.Status) + ")"
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	if p.cause != nil {
+		msg += ": " + p.cause.Error()
+	}
+
+	return msg
+}
+
+// Unwrap method for getting the cause of the problem.
+func (p *Problem) Unwrap() error {
+	return p.cause
+}
+
+// BadRequest func for 400 problem with code and detail.
+func BadRequest(code, detail string) *Problem {
+	return New(http.StatusBadRequest, code, detail)
+}
+
+// InvalidID func for 400 problem of a malformed ID in path parameter name.
+func InvalidID(name string, err error) *Problem {
+	return Wrap(err, http.StatusBadRequest, CodeInvalidID, name+" must be a UUID")
+}
+
+// InvalidBody func for 400 problem of a body which cannot be parsed.
+func InvalidBody(err error) *Problem {
+	return Wrap(err, http.StatusBadRequest, CodeInvalidBody, err.Error())
+}
+
+// NotFound func for 404 problem with code and detail.
+func NotFound(code, detail string) *Problem {
+	return New(http.StatusNotFound, code, detail)
+}
+
+// Unauthorized func for 401 problem with code and detail.
+func Unauthorized(code, detail string) *Problem {
+	return New(http.StatusUnauthorized, code, detail)
+}
+
+// Internal func for 500 problem, err is logged but not sent.
+func Internal(err error) *Problem {
+	return Wrap(err, http.StatusInternalServerError, CodeInternal, "")
+}
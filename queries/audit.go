@@ -0,0 +1,80 @@
+package queries
+
+import (
+	"opendavinci/models"
+)
+
+// AuditQueries struct for queries from AuditEntry model.
+// Changes of rows are recorded by triggers, see the audit migration, the log is never updated.
+type AuditQueries struct {
+	*DB
+}
+
+// auditColumns are the columns of the AuditEntry model, missing before and after are read as JSON null.
+const auditColumns = `id, created, actor, action, resourcetype, resourceid,
+	COALESCE(before, 'null') AS before, COALESCE(after, 'null') AS after, requestid, ip, prevhash, hash`
+
+// CreateAuditEntry method for appending entry e of an event without a row of its own, e.g. an auth event.
+func (q *AuditQueries) CreateAuditEntry(e *models.AuditEntry) error {
+	// Define query string.
+	query := `SELECT audit_append($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	// JSON of before and after is stored as sent, NULL if there is none.
+	var before, after []byte
+	if len(e.Before) > 0 {
+		before = e.Before
+	}
+	if len(e.After) > 0 {
+		after = e.After
+	}
+
+	// Send query to database.
+	_, err := q.Exec(query, e.Actor, e.Action, e.ResourceType, e.ResourceID, before, after, e.RequestID, e.IP)
+
+	return err
+}
+
+// GetAuditEntries method for getting up to f.Limit entries matching filter f, newest first.
+func (q *AuditQueries) GetAuditEntries(f *models.AuditFilter) ([]models.AuditEntry, error) {
+	// Define entries variable.
+	entries := []models.AuditEntry{}
+
+	// Define query string.
+	query := `SELECT ` + auditColumns + ` FROM audit_log
+		WHERE ($1 = '' OR actor = $1)
+			AND ($2 = '' OR resourcetype = $2)
+			AND ($3 = '' OR resourceid = $3)
+			AND ($4::timestamptz IS NULL OR created >= $4)
+			AND ($5::timestamptz IS NULL OR created < $5)
+			AND ($6 = 0 OR id < $6)
+		ORDER BY id DESC LIMIT $7`
+
+	// Send query to database.
+	err := q.Select(&entries, query, f.Actor, f.ResourceType, f.ResourceID, f.From, f.To, f.BeforeID, f.Limit)
+	if err != nil {
+		// Return empty object and error.
+		return entries, err
+	}
+
+	// Return query result.
+	return entries, nil
+}
+
+// GetAuditChain method for getting up to limit chained entries after the one by given ID, oldest first.
+func (q *AuditQueries) GetAuditChain(afterID int64, limit int) ([]models.AuditEntry, error) {
+	// Define entries variable.
+	entries := []models.AuditEntry{}
+
+	// Define query string.
+	query := `SELECT ` + auditColumns + ` FROM audit_log WHERE hash IS NOT NULL AND id > $1 ORDER BY id LIMIT $2`
+
+	// Send query to database.
+	err := q.Select(&entries, query, afterID, limit)
+	if err != nil {
+		// Return empty object and error.
+		return entries, err
+	}
+
+	// Return query result.
+	return entries, nil
+}
@@ -0,0 +1,110 @@
+package queries
+
+import (
+	"opendavinci/models"
+)
+
+// CatalogQueries struct for queries of catalog import and export.
+type CatalogQueries struct {
+	*DB
+}
+
+// ImportCourse method for upserting course and its lessons by their natural keys.
+// With dryRun all changes are rolled back, but the result is reported as usual.
+func (q *CatalogQueries) ImportCourse(b *models.CatalogCourse, dryRun bool) (*models.ImportResult, error) {
+	result := &models.ImportResult{CourseID: b.CourseID}
+
+	// Course and its lessons are imported together or not at all.
+	tx, err := q.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Define query string.
+	query := `INSERT INTO courses (rawdata) VALUES ($1)
+		ON CONFLICT ((rawdata ->> 'courseid')) DO UPDATE SET rawdata = courses.rawdata || EXCLUDED.rawdata
+		RETURNING (xmax = 0) AS inserted`
+
+	js, err := courseRawData(&b.Course)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send query to database.
+	var inserted bool
+	if err := tx.Get(&inserted, query, js); err != nil {
+		return nil, err
+	}
+	result.Action = action(inserted)
+
+	// Define query string.
+	query = `INSERT INTO lessons (rawdata) VALUES ($1)
+		ON CONFLICT ((rawdata ->> 'lessonid')) DO UPDATE SET rawdata = lessons.rawdata || EXCLUDED.rawdata
+		RETURNING (xmax = 0) AS inserted`
+
+	for i := range b.Lessons {
+		lesson := &b.Lessons[i]
+		lesson.CourseID = b.CourseID
+
+		js, err := lessonRawData(lesson)
+		if err != nil {
+			return nil, err
+		}
+
+		// Send query to database.
+		if err := tx.Get(&inserted, query, js); err != nil {
+			return nil, err
+		}
+		if inserted {
+			result.LessonsCreated++
+		} else {
+			result.LessonsUpdated++
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	return result, tx.Commit()
+}
+
+// ExportCourses method for calling fn with every course and its lessons.
+// Lessons are read course by course, so they are never all kept in memory.
+func (q *CatalogQueries) ExportCourses(fn func(*models.CatalogCourse) error) error {
+	// Define courses variable.
+	courses := []models.Course{}
+
+	// Define query string.
+	query := `SELECT * FROM courses_v ORDER BY courseid`
+
+	// Send query to database.
+	if err := q.Select(&courses, query); err != nil {
+		return err
+	}
+
+	lessons := &LessonQueries{DB: q.DB}
+	for _, c := range courses {
+		course := &models.CatalogCourse{Course: c}
+
+		var err error
+		if course.Lessons, err = lessons.GetLessonsByCourse(c.CourseID); err != nil {
+			return err
+		}
+
+		if err := fn(course); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func action(inserted bool) string {
+	if inserted {
+		return "created"
+	}
+
+	return "updated"
+}
@@ -1,14 +1,19 @@
 package queries
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 	"opendavinci/models"
 )
 
 // CourseQueries struct for queries from Course model.
 type CourseQueries struct {
-	*sqlx.DB
+	*DB
 }
 
 // GetCourses method for getting all courses.
@@ -30,6 +35,64 @@ func (q *CourseQueries) GetCourses() ([]models.Course, error) {
 	return courses, nil
 }
 
+// GetCoursesAfter method for getting a page of courses ordered by creation,
+// it starts after the course of the cursor.
+func (q *CourseQueries) GetCoursesAfter(after CourseCursor, limit int) ([]models.Course, error) {
+	// Define courses variable.
+	courses := []models.Course{}
+
+	// Define query string.
+	query := `SELECT * FROM courses_v WHERE (created, id) > ($1, $2) ORDER BY created, id LIMIT $3`
+
+	// Send query to database.
+	err := q.Select(&courses, query, after.Created, after.ID, limit)
+	if err != nil {
+		// Return empty object and error.
+		return courses, err
+	}
+
+	// Return query result.
+	return courses, nil
+}
+
+// GetCoursesByIDs method for getting the courses with the given IDs, in any order.
+func (q *CourseQueries) GetCoursesByIDs(ids []uuid.UUID) ([]models.Course, error) {
+	// Define courses variable.
+	courses := []models.Course{}
+
+	// Define query string.
+	query := `SELECT * FROM courses_v WHERE id = ANY($1)`
+
+	// Send query to database.
+	err := q.Select(&courses, query, ids)
+	if err != nil {
+		// Return empty object and error.
+		return courses, err
+	}
+
+	// Return query result.
+	return courses, nil
+}
+
+// GetCoursesByKeys method for getting the courses with the given course keys, in any order.
+func (q *CourseQueries) GetCoursesByKeys(courseIDs []string) ([]models.Course, error) {
+	// Define courses variable.
+	courses := []models.Course{}
+
+	// Define query string.
+	query := `SELECT * FROM courses_v WHERE courseid = ANY($1)`
+
+	// Send query to database.
+	err := q.Select(&courses, query, courseIDs)
+	if err != nil {
+		// Return empty object and error.
+		return courses, err
+	}
+
+	// Return query result.
+	return courses, nil
+}
+
 // GetCourse method for getting one course by given ID.
 func (q *CourseQueries) GetCourse(id uuid.UUID) (models.Course, error) {
 	// Define course variable.
@@ -49,11 +112,19 @@ func (q *CourseQueries) GetCourse(id uuid.UUID) (models.Course, error) {
 	return course, nil
 }
 
-// CreateCourse method for creating course by given json.
-func (q *CourseQueries) CreateCourse(js string) error {
-	query := `INSERT INTO courses (rawdata) VALUES ($1)`
+// CreateCourse method for creating course by given Course object.
+func (q *CourseQueries) CreateCourse(b *models.Course) error {
+	// Define query string.
+	query := `INSERT INTO courses (id, created, rawdata) VALUES ($1, $2, $3)`
+
+	// Build JSON document for the courses_v view.
+	js, err := courseRawData(b)
+	if err != nil {
+		return err
+	}
 
-	_, err := q.Exec(query, js)
+	// Send query to database.
+	_, err = q.Exec(query, b.ID, b.Created, js)
 	if err != nil {
 		// Return only error.
 		return err
@@ -68,8 +139,30 @@ func (q *CourseQueries) UpdateCourse(id uuid.UUID, b *models.Course) error {
 	// Define query string.
 	query := `UPDATE courses SET rawdata = $2 WHERE id = $1`
 
+	// Build JSON document for the courses_v view.
+	js, err := courseRawData(b)
+	if err != nil {
+		return err
+	}
+
 	// Send query to database.
-	_, err := q.Exec(query, id, b.CourseID)
+	_, err = q.Exec(query, id, js)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// UpdateCourseImage method for setting the image blob key of course by given ID.
+func (q *CourseQueries) UpdateCourseImage(id uuid.UUID, key string) error {
+	// Define query string.
+	query := `UPDATE courses SET rawdata = jsonb_set(rawdata, '{image}', to_jsonb($2::text)) WHERE id = $1`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, key)
 	if err != nil {
 		// Return only error.
 		return err
@@ -94,3 +187,68 @@ func (q *CourseQueries) DeleteCourse(id uuid.UUID) error {
 	// This query returns nothing.
 	return nil
 }
+
+// courseRawData func for building the rawdata JSON of the given course.
+// Keys must match the ones extracted by the courses_v view.
+func courseRawData(b *models.Course) (string, error) {
+	js, err := json.Marshal(map[string]string{
+		"courseid":    b.CourseID,
+		"title":       b.Title,
+		"description": b.Descriptions,
+		"image":       b.Image,
+		"subject":     b.Subject,
+		"instructor":  b.Instructor,
+		"updated":     b.Updated,
+		"published":   b.Published,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(js), nil
+}
+
+// CourseCursor struct to describe the position of a course in pages ordered by creation.
+// The zero cursor is before all courses.
+type CourseCursor struct {
+	Created time.Time
+	ID      uuid.UUID
+}
+
+// CourseCursorOf func for getting the cursor after course.
+func CourseCursorOf(course models.Course) CourseCursor {
+	return CourseCursor{Created: course.Created, ID: course.ID}
+}
+
+// String method for getting the opaque cursor sent to clients.
+func (c CourseCursor) String() string {
+	raw := c.Created.UTC().Format(time.RFC3339Nano) + " " + c.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCourseCursor func for parsing a cursor sent by a client, the empty cursor is the zero one.
+func ParseCourseCursor(s string) (CourseCursor, error) {
+	if s == "" {
+		return CourseCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return CourseCursor{}, err
+	}
+	created, id, ok := strings.Cut(string(raw), " ")
+	if !ok {
+		return CourseCursor{}, errors.New("cursor without ID")
+	}
+
+	var c CourseCursor
+	if c.Created, err = time.Parse(time.RFC3339Nano, created); err != nil {
+		return CourseCursor{}, err
+	}
+	if c.ID, err = uuid.Parse(id); err != nil {
+		return CourseCursor{}, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,204 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"runtime"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"opendavinci/audit"
+	"opendavinci/tracing"
+)
+
+// DB struct to describe the connection pool bound to the context of a request.
+// Every query runs within a child span of the span in Ctx.
+type DB struct {
+	*sqlx.DB
+	Ctx context.Context
+	tx  *sqlx.Tx // set within InTx, queries run in the transaction
+}
+
+// Tx struct to describe a transaction, its queries are traced like the ones of DB.
+type Tx struct {
+	*sqlx.Tx
+	ctx context.Context
+}
+
+// NewDB func for binding the connection pool to ctx.
+func NewDB(ctx context.Context, db *sqlx.DB) *DB {
+	return &DB{DB: db, Ctx: ctx}
+}
+
+// Select method for running query and scanning all rows into dest.
+func (db *DB) Select(dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startSpan(db.Ctx, query)
+	var err error
+	if db.tx != nil {
+		err = db.tx.SelectContext(ctx, dest, query, args...)
+	} else {
+		err = db.DB.SelectContext(ctx, dest, query, args...)
+	}
+	return endSpan(span, err)
+}
+
+// Get method for running query and scanning one row into dest.
+func (db *DB) Get(dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startSpan(db.Ctx, query)
+	var err error
+	if db.tx != nil {
+		err = db.tx.GetContext(ctx, dest, query, args...)
+	} else {
+		err = db.DB.GetContext(ctx, dest, query, args...)
+	}
+	return endSpan(span, err)
+}
+
+// Exec method for running query without rows.
+// With an actor in Ctx it runs in a transaction of its own, so audit triggers record the actor.
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startSpan(db.Ctx, query)
+	var res sql.Result
+	var err error
+	if db.tx != nil {
+		res, err = db.tx.ExecContext(ctx, query, args...)
+	} else if a, ok := audit.ActorFrom(db.Ctx); ok {
+		var tx *sqlx.Tx
+		if tx, err = db.DB.BeginTxx(ctx, nil); err == nil {
+			if err = setActor(ctx, tx, a); err == nil {
+				res, err = tx.ExecContext(ctx, query, args...)
+			}
+			if err == nil {
+				err = tx.Commit()
+			} else {
+				tx.Rollback()
+			}
+		}
+	} else {
+		res, err = db.DB.ExecContext(ctx, query, args...)
+	}
+	return res, endSpan(span, err)
+}
+
+// InTx method for running fn with queries in one transaction, it is committed if fn returns nil.
+// Within a transaction fn runs in it, e.g. to enqueue jobs together with the change they are for.
+func (db *DB) InTx(fn func(tx *DB) error) error {
+	if db.tx != nil {
+		return fn(db)
+	}
+
+	ctx, span := startSpan(db.Ctx, "BEGIN")
+	tx, err := db.DB.BeginTxx(ctx, nil)
+	if err := endSpan(span, err); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if a, ok := audit.ActorFrom(db.Ctx); ok {
+		if err := setActor(ctx, tx, a); err != nil {
+			return err
+		}
+	}
+
+	if err := fn(&DB{DB: db.DB, Ctx: db.Ctx, tx: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Beginx method for starting a transaction, it is rolled back when Ctx is done.
+// It is separate from the one of InTx, if any.
+func (db *DB) Beginx() (*Tx, error) {
+	ctx, span := startSpan(db.Ctx, "BEGIN")
+	tx, err := db.DB.BeginTxx(ctx, nil)
+	if err := endSpan(span, err); err != nil {
+		return nil, err
+	}
+
+	if a, ok := audit.ActorFrom(db.Ctx); ok {
+		if err := setActor(ctx, tx, a); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	return &Tx{Tx: tx, ctx: db.Ctx}, nil
+}
+
+// Select method for running query and scanning all rows into dest.
+func (tx *Tx) Select(dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startSpan(tx.ctx, query)
+	err := tx.Tx.SelectContext(ctx, dest, query, args...)
+	return endSpan(span, err)
+}
+
+// Get method for running query and scanning one row into dest.
+func (tx *Tx) Get(dest interface{}, query string, args ...interface{}) error {
+	ctx, span := startSpan(tx.ctx, query)
+	err := tx.Tx.GetContext(ctx, dest, query, args...)
+	return endSpan(span, err)
+}
+
+// Exec method for running query without rows.
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startSpan(tx.ctx, query)
+	res, err := tx.Tx.ExecContext(ctx, query, args...)
+	return res, endSpan(span, err)
+}
+
+// setActor func for setting the actor of the changes made in tx, for the audit triggers.
+func setActor(ctx context.Context, tx *sqlx.Tx, a audit.Actor) error {
+	_, err := tx.ExecContext(ctx, `SELECT set_config('opendavinci.actor', $1, true),
+		set_config('opendavinci.request_id', $2, true), set_config('opendavinci.ip', $3, true)`,
+		a.ID, a.RequestID, a.IP)
+
+	return err
+}
+
+// startSpan starts a client span named after the calling query method, e.g. CourseQueries.GetCourse.
+func startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return tracing.Tracer().Start(ctx, queryName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBQueryText(query),
+		),
+	)
+}
+
+// endSpan ends span, errors other than no rows mark the span as failed.
+func endSpan(span trace.Span, err error) error {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	return err
+}
+
+// queryName returns the name of the method which called DB or Tx.
+func queryName() string {
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "query"
+	}
+
+	// opendavinci/queries.(*CourseQueries).GetCourse -> CourseQueries.GetCourse
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name, "queries.")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+
+	return name
+}
@@ -0,0 +1,64 @@
+package queries
+
+import (
+	"github.com/google/uuid"
+	"opendavinci/models"
+)
+
+// EnrollmentQueries struct for queries from Enrollment model.
+type EnrollmentQueries struct {
+	*DB
+}
+
+// GetEnrollmentsByCourses method for getting the enrollments in the courses with the given IDs.
+func (q *EnrollmentQueries) GetEnrollmentsByCourses(courseIDs []uuid.UUID) ([]models.Enrollment, error) {
+	// Define enrollments variable.
+	enrollments := []models.Enrollment{}
+
+	// Define query string.
+	query := `SELECT * FROM enrollments_v WHERE courseid = ANY($1) ORDER BY created, id`
+
+	// Send query to database.
+	err := q.Select(&enrollments, query, courseIDs)
+	if err != nil {
+		// Return empty object and error.
+		return enrollments, err
+	}
+
+	// Return query result.
+	return enrollments, nil
+}
+
+// GetEnrollmentsByUsers method for getting the enrollments of the users with the given IDs.
+func (q *EnrollmentQueries) GetEnrollmentsByUsers(userIDs []uuid.UUID) ([]models.Enrollment, error) {
+	// Define enrollments variable.
+	enrollments := []models.Enrollment{}
+
+	// Define query string.
+	query := `SELECT * FROM enrollments_v WHERE userid = ANY($1) ORDER BY created, id`
+
+	// Send query to database.
+	err := q.Select(&enrollments, query, userIDs)
+	if err != nil {
+		// Return empty object and error.
+		return enrollments, err
+	}
+
+	// Return query result.
+	return enrollments, nil
+}
+
+// CountEnrollments method for getting the number of all and of completed enrollments.
+func (q *EnrollmentQueries) CountEnrollments() (models.EnrollmentCounts, error) {
+	// Define counts variable.
+	counts := models.EnrollmentCounts{}
+
+	// Define query string.
+	query := `SELECT COUNT(*) AS total, COUNT(completed) AS completed FROM enrollments_v`
+
+	// Send query to database.
+	err := q.Get(&counts, query)
+
+	// Return query result.
+	return counts, err
+}
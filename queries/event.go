@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"time"
+
+	"opendavinci/models"
+)
+
+// EventQueries struct for queries from Event model.
+// On Postgres, IDs are taken before commit, so a later ID can commit first. Events are read
+// once their transaction and all before it ended, ordered by transaction and ID, so no event
+// commits behind one read before.
+type EventQueries struct {
+	*DB
+}
+
+// GetEventsAfter method for getting up to limit events after the event with ID after, in order of the feed.
+// If the event was deleted already, the events with a greater ID are read.
+func (q *EventQueries) GetEventsAfter(after int64, limit int) ([]models.Event, error) {
+	// Define events variable.
+	events := []models.Event{}
+
+	// Define query string.
+	query := `WITH last AS (SELECT txid, id FROM change_events WHERE id = $1)
+		SELECT e.id, e.created, e.type, e.action, e.resourceid, e.txid::text::bigint AS txid
+		FROM change_events e
+		WHERE e.txid < pg_snapshot_xmin(pg_current_snapshot())
+		AND ((e.txid, e.id) > (SELECT txid, id FROM last) OR NOT EXISTS (SELECT FROM last) AND e.id > $1)
+		ORDER BY e.txid, e.id LIMIT $2`
+
+	// Send query to database.
+	err := q.Select(&events, query, after, limit)
+	if err != nil {
+		// Return empty object and error.
+		return events, err
+	}
+
+	// Return query result.
+	return events, nil
+}
+
+// GetLatestEventID method for getting the ID of the last event of the feed, 0 if there is none.
+func (q *EventQueries) GetLatestEventID() (int64, error) {
+	// Define ID variable.
+	var id int64
+
+	// Define query string.
+	query := `SELECT COALESCE((SELECT id FROM change_events
+		WHERE txid < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY txid DESC, id DESC LIMIT 1), 0)`
+
+	// Send query to database.
+	err := q.Get(&id, query)
+
+	return id, err
+}
+
+// DeleteEvents method for removing events created before the given time.
+func (q *EventQueries) DeleteEvents(before time.Time) error {
+	// Define query string.
+	query := `DELETE FROM change_events WHERE created < $1`
+
+	// Send query to database.
+	_, err := q.Exec(query, before)
+
+	return err
+}
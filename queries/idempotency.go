@@ -0,0 +1,93 @@
+package queries
+
+import (
+	"time"
+
+	"opendavinci/models"
+)
+
+// IdempotencyQueries struct for queries from IdempotencyKey model.
+type IdempotencyQueries struct {
+	*DB
+}
+
+// ClaimIdempotencyKey method for storing key k in progress, before its first request is handled.
+// Expired keys and keys whose first request stopped before lockedUntil are taken over.
+// It reports false, if the key is stored already, GetIdempotencyKey gets it then.
+func (q *IdempotencyQueries) ClaimIdempotencyKey(k *models.IdempotencyKey) (bool, error) {
+	// Define claimed keys variable.
+	claimed := []string{}
+
+	// Define query string.
+	query := `INSERT INTO idempotency_keys AS k (userkey, key, fingerprint, lockeduntil, expires) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (userkey, key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint, status = 0, headers = '{}', body = '',
+			created = NOW(), lockeduntil = EXCLUDED.lockeduntil, expires = EXCLUDED.expires
+		WHERE k.expires < NOW() OR (k.status = 0 AND k.lockeduntil < NOW())
+		RETURNING key`
+
+	// Send query to database.
+	err := q.Select(&claimed, query, k.UserKey, k.Key, k.Fingerprint, k.LockedUntil, k.Expires)
+	if err != nil {
+		// Return only error.
+		return false, err
+	}
+
+	// Return query result.
+	return len(claimed) > 0, nil
+}
+
+// GetIdempotencyKey method for getting one key of the user by given key.
+func (q *IdempotencyQueries) GetIdempotencyKey(userKey, key string) (models.IdempotencyKey, error) {
+	// Define key variable.
+	k := models.IdempotencyKey{}
+
+	// Define query string.
+	query := `SELECT * FROM idempotency_keys WHERE userkey = $1 AND key = $2`
+
+	// Send query to database.
+	err := q.Get(&k, query, userKey, key)
+	if err != nil {
+		// Return empty object and error.
+		return k, err
+	}
+
+	// Return query result.
+	return k, nil
+}
+
+// SaveIdempotentResponse method for storing the response to the first request of key k.
+// Nothing changes, if a retry took over the key meanwhile.
+func (q *IdempotencyQueries) SaveIdempotentResponse(k *models.IdempotencyKey) error {
+	// Define query string.
+	query := `UPDATE idempotency_keys SET status = $1, headers = $2, body = $3
+		WHERE userkey = $4 AND key = $5 AND fingerprint = $6 AND status = 0`
+
+	// Send query to database.
+	_, err := q.Exec(query, k.Status, k.Headers, k.Body, k.UserKey, k.Key, k.Fingerprint)
+
+	return err
+}
+
+// ReleaseIdempotencyKey method for deleting key k in progress, so a retry is handled again,
+// e.g. after the first request failed with a server error.
+func (q *IdempotencyQueries) ReleaseIdempotencyKey(k *models.IdempotencyKey) error {
+	// Define query string.
+	query := `DELETE FROM idempotency_keys WHERE userkey = $1 AND key = $2 AND fingerprint = $3 AND status = 0`
+
+	// Send query to database.
+	_, err := q.Exec(query, k.UserKey, k.Key, k.Fingerprint)
+
+	return err
+}
+
+// DeleteIdempotencyKeys method for removing keys which expired before the given time.
+func (q *IdempotencyQueries) DeleteIdempotencyKeys(before time.Time) error {
+	// Define query string.
+	query := `DELETE FROM idempotency_keys WHERE expires < $1`
+
+	// Send query to database.
+	_, err := q.Exec(query, before)
+
+	return err
+}
@@ -0,0 +1,174 @@
+package queries
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"opendavinci/models"
+)
+
+// JobQueries struct for queries from Job model.
+// Jobs are claimed with FOR UPDATE SKIP LOCKED, so the queue needs Postgres.
+type JobQueries struct {
+	*DB
+}
+
+// jobColumns are the columns of the Job model.
+const jobColumns = `id, created, kind, args, uniquekey, status, attempts, runat, lockeduntil, lockedby, lasterror, finished`
+
+// InsertJob method for queueing job by given Job object, it sets ID and created of j.
+// It reports false, if a job of the kind with the unique key is pending or running already.
+func (q *JobQueries) InsertJob(j *models.Job) (bool, error) {
+	// Define query string.
+	query := `INSERT INTO jobs (kind, args, uniquekey, runat) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kind, uniquekey) WHERE uniquekey IS NOT NULL AND status IN ('pending', 'running') DO NOTHING
+		RETURNING id, created`
+
+	// Send query to database.
+	err := q.Get(j, query, j.Kind, []byte(j.Args), j.UniqueKey, j.RunAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ClaimJobs method for locking up to limit due jobs of the kinds for worker until lease.
+// Running jobs whose lease ended are claimed again, their worker stopped on the way.
+// Each claim counts as an attempt, attempts is the fencing token of the outcome.
+func (q *JobQueries) ClaimJobs(kinds []string, worker string, lease time.Time, limit int) ([]models.Job, error) {
+	// Define jobs variable.
+	jobs := []models.Job{}
+
+	// Define query string.
+	query := `UPDATE jobs SET status = 'running', attempts = attempts + 1, lockeduntil = $1, lockedby = $2
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE kind = ANY($3) AND (
+				(status = 'pending' AND runat <= NOW()) OR (status = 'running' AND lockeduntil < NOW())
+			)
+			ORDER BY runat, id
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	// Send query to database.
+	err := q.Select(&jobs, query, lease, worker, kinds, limit)
+	if err != nil {
+		// Return empty object and error.
+		return jobs, err
+	}
+
+	// Return query result.
+	return jobs, nil
+}
+
+// CompleteJob method for setting job by given ID and attempt done.
+// Nothing changes, if the job was claimed again after the lease of the attempt ended.
+func (q *JobQueries) CompleteJob(id int64, attempt int) error {
+	// Define query string.
+	query := `UPDATE jobs SET status = 'done', lockeduntil = NULL, finished = NOW()
+		WHERE id = $1 AND attempts = $2 AND status = 'running'`
+
+	// Send query to database.
+	_, err := q.Exec(query, id, attempt)
+
+	return err
+}
+
+// RetryJob method for queueing job by given ID and attempt again at runAt, after it failed with lastError.
+func (q *JobQueries) RetryJob(id int64, attempt int, runAt time.Time, lastError string) error {
+	// Define query string.
+	query := `UPDATE jobs SET status = 'pending', runat = $1, lasterror = $2, lockeduntil = NULL
+		WHERE id = $3 AND attempts = $4 AND status = 'running'`
+
+	// Send query to database.
+	_, err := q.Exec(query, runAt, lastError, id, attempt)
+
+	return err
+}
+
+// BuryJob method for moving job by given ID and attempt to the dead jobs, after its last attempt failed.
+func (q *JobQueries) BuryJob(id int64, attempt int, lastError string) error {
+	// Define query string.
+	query := `UPDATE jobs SET status = 'dead', lasterror = $1, lockeduntil = NULL, finished = NOW()
+		WHERE id = $2 AND attempts = $3 AND status = 'running'`
+
+	// Send query to database.
+	_, err := q.Exec(query, lastError, id, attempt)
+
+	return err
+}
+
+// GetJobs method for getting up to limit jobs with the status, of the kind if it is set, newest first.
+func (q *JobQueries) GetJobs(status, kind string, limit int) ([]models.Job, error) {
+	// Define jobs variable.
+	jobs := []models.Job{}
+
+	// Define query string.
+	query := `SELECT ` + jobColumns + ` FROM jobs
+		WHERE status = $1 AND ($2 = '' OR kind = $2)
+		ORDER BY id DESC LIMIT $3`
+
+	// Send query to database.
+	err := q.Select(&jobs, query, status, kind, limit)
+	if err != nil {
+		// Return empty object and error.
+		return jobs, err
+	}
+
+	// Return query result.
+	return jobs, nil
+}
+
+// GetJob method for getting one job by given ID.
+func (q *JobQueries) GetJob(id int64) (models.Job, error) {
+	// Define job variable.
+	job := models.Job{}
+
+	// Define query string.
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	// Send query to database.
+	err := q.Get(&job, query, id)
+	if err != nil {
+		// Return empty object and error.
+		return job, err
+	}
+
+	// Return query result.
+	return job, nil
+}
+
+// RequeueJob method for queueing dead job by given ID again with all its attempts.
+// It returns sql.ErrNoRows, if there is no such dead job.
+func (q *JobQueries) RequeueJob(id int64) error {
+	// Define query string.
+	query := `UPDATE jobs SET status = 'pending', attempts = 0, runat = NOW(), lockedby = NULL, finished = NULL
+		WHERE id = $1 AND status = 'dead'`
+
+	// Send query to database.
+	res, err := q.Exec(query, id)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	return affected(res)
+}
+
+// DeleteJobs method for removing done jobs finished before the given time, dead ones are kept.
+func (q *JobQueries) DeleteJobs(before time.Time) error {
+	// Define query string.
+	query := `DELETE FROM jobs WHERE status = 'done' AND finished < $1`
+
+	// Send query to database.
+	_, err := q.Exec(query, before)
+
+	return err
+}
@@ -0,0 +1,119 @@
+package queries
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"opendavinci/models"
+)
+
+// LessonQueries struct for queries from Lesson model.
+type LessonQueries struct {
+	*DB
+}
+
+// GetLesson method for getting one lesson by given ID.
+func (q *LessonQueries) GetLesson(id uuid.UUID) (models.Lesson, error) {
+	// Define lesson variable.
+	lesson := models.Lesson{}
+
+	// Define query string.
+	query := `SELECT * FROM lessons_v WHERE id = $1`
+
+	// Send query to database.
+	err := q.Get(&lesson, query, id)
+	if err != nil {
+		// Return empty object and error.
+		return lesson, err
+	}
+
+	// Return query result.
+	return lesson, nil
+}
+
+// GetLessonsByCourse method for getting ordered lessons of course by given course key.
+func (q *LessonQueries) GetLessonsByCourse(courseID string) ([]models.Lesson, error) {
+	// Define lessons variable.
+	lessons := []models.Lesson{}
+
+	// Define query string.
+	query := `SELECT * FROM lessons_v WHERE courseid = $1 ORDER BY position, lessonid`
+
+	// Send query to database.
+	err := q.Select(&lessons, query, courseID)
+	if err != nil {
+		// Return empty object and error.
+		return lessons, err
+	}
+
+	// Return query result.
+	return lessons, nil
+}
+
+// GetLessonsByCourses method for getting ordered lessons of the courses by given course keys.
+func (q *LessonQueries) GetLessonsByCourses(courseIDs []string) ([]models.Lesson, error) {
+	// Define lessons variable.
+	lessons := []models.Lesson{}
+
+	// Define query string.
+	query := `SELECT * FROM lessons_v WHERE courseid = ANY($1) ORDER BY courseid, position, lessonid`
+
+	// Send query to database.
+	err := q.Select(&lessons, query, courseIDs)
+	if err != nil {
+		// Return empty object and error.
+		return lessons, err
+	}
+
+	// Return query result.
+	return lessons, nil
+}
+
+// AddLessonAttachment method for appending attachment to lesson by given ID.
+func (q *LessonQueries) AddLessonAttachment(id uuid.UUID, a *models.Attachment) error {
+	// Define query string.
+	query := `UPDATE lessons
+		SET rawdata = jsonb_set(rawdata, '{attachments}', COALESCE(rawdata -> 'attachments', '[]'::jsonb) || $2::jsonb)
+		WHERE id = $1`
+
+	// Attachment is appended as a one element array.
+	js, err := json.Marshal([]*models.Attachment{a})
+	if err != nil {
+		return err
+	}
+
+	// Send query to database.
+	_, err = q.Exec(query, id, string(js))
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	// This query returns nothing.
+	return nil
+}
+
+// lessonRawData func for building the rawdata JSON of the given lesson.
+// Keys must match the ones extracted by the lessons_v view.
+// Attachments are left out when nil, so merging keeps the stored ones.
+func lessonRawData(b *models.Lesson) (string, error) {
+	data := map[string]interface{}{
+		"lessonid":    b.LessonID,
+		"courseid":    b.CourseID,
+		"position":    b.Position,
+		"title":       b.Title,
+		"content":     b.Content,
+		"format":      b.Format,
+		"resourceurl": b.ResourceURL,
+	}
+	if b.Attachments != nil {
+		data["attachments"] = b.Attachments
+	}
+
+	js, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(js), nil
+}
@@ -0,0 +1,51 @@
+package queries
+
+import "time"
+
+// RateLimitQueries struct for queries of rate limit buckets.
+type RateLimitQueries struct {
+	*DB
+}
+
+// TakeRateLimitToken method for refilling the bucket of key and taking one token.
+// Buckets are refilled by the clock of the database, so instances need not agree on time.
+// It returns tokens left and if the request is allowed.
+func (q *RateLimitQueries) TakeRateLimitToken(key string, rate float64, burst int) (float64, bool, error) {
+	// Define result variable.
+	row := struct {
+		Tokens  float64 `db:"tokens"`
+		Allowed bool    `db:"allowed"`
+	}{}
+
+	// Define query string, a new bucket is full. Concurrent requests of the key
+	// wait for the row lock, so each of them sees the tokens left by the previous one.
+	query := `
+	INSERT INTO rate_limits AS r (key, tokens, allowed, updated)
+	VALUES ($1, $3::float8 - 1, true, now())
+	ON CONFLICT (key) DO UPDATE SET
+		tokens = LEAST($3::float8, r.tokens + EXTRACT(EPOCH FROM now() - r.updated)::float8 * $2::float8) - (LEAST($3::float8, r.tokens + EXTRACT(EPOCH FROM now() - r.updated)::float8 * $2::float8) >= 1)::int,
+		allowed = LEAST($3::float8, r.tokens + EXTRACT(EPOCH FROM now() - r.updated)::float8 * $2::float8) >= 1,
+		updated = now()
+	RETURNING tokens, allowed`
+
+	// Send query to database.
+	err := q.Get(&row, query, key, rate, burst)
+	if err != nil {
+		// Return empty result and error.
+		return 0, false, err
+	}
+
+	// Return query result.
+	return row.Tokens, row.Allowed, nil
+}
+
+// DeleteRateLimits method for removing buckets which were not used for the given time.
+func (q *RateLimitQueries) DeleteRateLimits(unused time.Duration) error {
+	// Define query string.
+	query := `DELETE FROM rate_limits WHERE updated < now() - make_interval(secs => $1)`
+
+	// Send query to database.
+	_, err := q.Exec(query, unused.Seconds())
+
+	return err
+}
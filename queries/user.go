@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"github.com/google/uuid"
+	"opendavinci/models"
+)
+
+// UserQueries struct for queries from User model.
+type UserQueries struct {
+	*DB
+}
+
+// GetUser method for getting one user by given ID.
+func (q *UserQueries) GetUser(id uuid.UUID) (models.User, error) {
+	// Define user variable.
+	user := models.User{}
+
+	// Define query string.
+	query := `SELECT id, created, COALESCE(email, '') AS email, COALESCE(rbacrole, '') AS rbacrole FROM users_v WHERE id = $1`
+
+	// Send query to database.
+	err := q.Get(&user, query, id)
+	if err != nil {
+		// Return empty object and error.
+		return user, err
+	}
+
+	// Return query result.
+	return user, nil
+}
+
+// GetUsersByIDs method for getting the users with the given IDs, in any order.
+func (q *UserQueries) GetUsersByIDs(ids []uuid.UUID) ([]models.User, error) {
+	// Define users variable.
+	users := []models.User{}
+
+	// Define query string.
+	query := `SELECT id, created, COALESCE(email, '') AS email, COALESCE(rbacrole, '') AS rbacrole FROM users_v WHERE id = ANY($1)`
+
+	// Send query to database.
+	err := q.Select(&users, query, ids)
+	if err != nil {
+		// Return empty object and error.
+		return users, err
+	}
+
+	// Return query result.
+	return users, nil
+}
+
+// GetUserByAPIKeyHash method for getting the user of an API key by the hex SHA-256 of the key.
+func (q *UserQueries) GetUserByAPIKeyHash(hash string) (models.User, error) {
+	// Define user variable.
+	user := models.User{}
+
+	// Define query string.
+	query := `SELECT id, created, COALESCE(rawdata ->> 'email', '') AS email, COALESCE(rawdata ->> 'rbacrole', '') AS rbacrole
+		FROM users WHERE rawdata ->> 'apikeyhash' = $1`
+
+	// Send query to database.
+	err := q.Get(&user, query, hash)
+	if err != nil {
+		// Return empty object and error.
+		return user, err
+	}
+
+	// Return query result.
+	return user, nil
+}
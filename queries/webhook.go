@@ -0,0 +1,221 @@
+package queries
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"opendavinci/models"
+)
+
+// WebhookQueries struct for queries from Webhook, Delivery and DeliveryAttempt models.
+// Deliveries of events are queued by triggers of their tables, see the webhooks and jobs migrations.
+type WebhookQueries struct {
+	*DB
+}
+
+// deliveryColumns are the columns of the Delivery model.
+const deliveryColumns = `id, created, webhookid, eventid, event, payload, redelivery, status, attempts, nextattempt, responsestatus, error`
+
+// GetWebhooks method for getting all webhooks ordered by creation.
+func (q *WebhookQueries) GetWebhooks() ([]models.Webhook, error) {
+	// Define webhooks variable.
+	webhooks := []models.Webhook{}
+
+	// Define query string.
+	query := `SELECT * FROM webhooks ORDER BY created, id`
+
+	// Send query to database.
+	err := q.Select(&webhooks, query)
+	if err != nil {
+		// Return empty object and error.
+		return webhooks, err
+	}
+
+	// Return query result.
+	return webhooks, nil
+}
+
+// GetWebhook method for getting one webhook by given ID.
+func (q *WebhookQueries) GetWebhook(id uuid.UUID) (models.Webhook, error) {
+	// Define webhook variable.
+	webhook := models.Webhook{}
+
+	// Define query string.
+	query := `SELECT * FROM webhooks WHERE id = $1`
+
+	// Send query to database.
+	err := q.Get(&webhook, query, id)
+	if err != nil {
+		// Return empty object and error.
+		return webhook, err
+	}
+
+	// Return query result.
+	return webhook, nil
+}
+
+// CreateWebhook method for creating webhook by given Webhook object.
+func (q *WebhookQueries) CreateWebhook(w *models.Webhook) error {
+	// Define query string.
+	query := `INSERT INTO webhooks (id, created, url, events, secret, active) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	// Send query to database.
+	_, err := q.Exec(query, w.ID, w.Created, w.URL, w.Events, w.Secret, w.Active)
+
+	return err
+}
+
+// UpdateWebhook method for updating URL, events and active of webhook by given ID,
+// the secret is replaced only if w has one. It returns sql.ErrNoRows, if there is no such webhook.
+func (q *WebhookQueries) UpdateWebhook(id uuid.UUID, w *models.Webhook) error {
+	// Define query string.
+	query := `UPDATE webhooks SET url = $1, events = $2, active = $3, secret = COALESCE(NULLIF($4, ''), secret) WHERE id = $5`
+
+	// Send query to database.
+	res, err := q.Exec(query, w.URL, w.Events, w.Active, w.Secret, id)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	return affected(res)
+}
+
+// DeleteWebhook method for deleting webhook by given ID with its deliveries.
+// It returns sql.ErrNoRows, if there is no such webhook.
+func (q *WebhookQueries) DeleteWebhook(id uuid.UUID) error {
+	// Define query string.
+	query := `DELETE FROM webhooks WHERE id = $1`
+
+	// Send query to database.
+	res, err := q.Exec(query, id)
+	if err != nil {
+		// Return only error.
+		return err
+	}
+
+	return affected(res)
+}
+
+// GetDeliveries method for getting up to limit deliveries of webhook by given ID, newest first.
+func (q *WebhookQueries) GetDeliveries(webhookID uuid.UUID, limit int) ([]models.Delivery, error) {
+	// Define deliveries variable.
+	deliveries := []models.Delivery{}
+
+	// Define query string.
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhookid = $1 ORDER BY created DESC, id LIMIT $2`
+
+	// Send query to database.
+	err := q.Select(&deliveries, query, webhookID, limit)
+	if err != nil {
+		// Return empty object and error.
+		return deliveries, err
+	}
+
+	// Return query result.
+	return deliveries, nil
+}
+
+// GetDelivery method for getting one delivery by given ID.
+func (q *WebhookQueries) GetDelivery(id uuid.UUID) (models.Delivery, error) {
+	// Define delivery variable.
+	delivery := models.Delivery{}
+
+	// Define query string.
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	// Send query to database.
+	err := q.Get(&delivery, query, id)
+	if err != nil {
+		// Return empty object and error.
+		return delivery, err
+	}
+
+	// Return query result.
+	return delivery, nil
+}
+
+// GetDeliveryAttempts method for getting the attempts of delivery by given ID, oldest first.
+func (q *WebhookQueries) GetDeliveryAttempts(deliveryID uuid.UUID) ([]models.DeliveryAttempt, error) {
+	// Define attempts variable.
+	attempts := []models.DeliveryAttempt{}
+
+	// Define query string.
+	query := `SELECT * FROM webhook_attempts WHERE deliveryid = $1 ORDER BY id`
+
+	// Send query to database.
+	err := q.Select(&attempts, query, deliveryID)
+	if err != nil {
+		// Return empty object and error.
+		return attempts, err
+	}
+
+	// Return query result.
+	return attempts, nil
+}
+
+// CreateDelivery method for queueing delivery by given Delivery object, e.g. a redelivery.
+func (q *WebhookQueries) CreateDelivery(d *models.Delivery) error {
+	// Define query string.
+	query := `INSERT INTO webhook_deliveries (id, created, webhookid, eventid, event, payload, redelivery, status, nextattempt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	// Send query to database.
+	_, err := q.Exec(query, d.ID, d.Created, d.WebhookID, d.EventID, d.Event, []byte(d.Payload), d.Redelivery, d.Status, d.NextAttempt)
+
+	return err
+}
+
+// RecordAttempt method for adding attempt to the log and setting the outcome of delivery d.
+func (q *WebhookQueries) RecordAttempt(d *models.Delivery, a *models.DeliveryAttempt) error {
+	// Attempt and outcome are recorded together or not at all.
+	tx, err := q.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Define query string.
+	query := `INSERT INTO webhook_attempts (created, deliveryid, responsestatus, responsebody, error, durationms)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	// Send query to database.
+	if _, err := tx.Exec(query, a.Created, d.ID, a.ResponseStatus, a.ResponseBody, a.Error, a.DurationMS); err != nil {
+		return err
+	}
+
+	// Define query string.
+	query = `UPDATE webhook_deliveries SET status = $1, attempts = $2, nextattempt = $3, responsestatus = $4, error = $5 WHERE id = $6`
+
+	// Send query to database.
+	if _, err := tx.Exec(query, d.Status, d.Attempts, d.NextAttempt, d.ResponseStatus, d.Error, d.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteDeliveries method for removing deliveries created before the given time, with their attempts.
+func (q *WebhookQueries) DeleteDeliveries(before time.Time) error {
+	// Define query string.
+	query := `DELETE FROM webhook_deliveries WHERE created < $1 AND status <> 'pending'`
+
+	// Send query to database.
+	_, err := q.Exec(query, before)
+
+	return err
+}
+
+// affected func for turning an update or delete of no rows into sql.ErrNoRows.
+func affected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
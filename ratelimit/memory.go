@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often full buckets are removed from memory.
+const sweepInterval = time.Minute
+
+// MemoryStore struct to describe buckets kept by one instance.
+// Each instance limits on its own, use PostgresStore to share limits.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time // when the bucket is full again and can be forgotten
+}
+
+// NewMemoryStore func for creating an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+		swept:   time.Now(),
+		now:     time.Now,
+	}
+}
+
+// Take method for taking one token from the bucket of key.
+func (s *MemoryStore) Take(_ context.Context, key string, l Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	key = l.Name + ":" + key
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens, r := take(refill(b.tokens, now.Sub(b.updated), l), l)
+	b.tokens, b.updated, b.full = tokens, now, now.Add(r.Reset)
+
+	return r, nil
+}
+
+// sweep removes buckets which are full again, they are the same as new ones.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.swept) < sweepInterval {
+		return
+	}
+	s.swept = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
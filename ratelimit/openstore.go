@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+
+	"opendavinci/config"
+)
+
+var (
+	storeMu sync.Mutex
+	store   Store
+)
+
+// OpenStore is our step to switch between diff bucket stores (memory/postgres).
+// The store is created once and shared by all requests.
+func OpenStore() (Store, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	if store != nil {
+		return store, nil
+	}
+
+	switch cfg := config.Get().RateLimit; cfg.Store {
+	case "", "memory":
+		store = NewMemoryStore()
+	case "postgres":
+		store = NewPostgresStore()
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+
+	return store, nil
+}
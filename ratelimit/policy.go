@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"net"
+	"strings"
+
+	"opendavinci/config"
+)
+
+// Rate limit policies, see config.RateLimit.
+const (
+	PolicyAnonymous = "anonymous" // public routes, by IP
+	PolicyToken     = "token"     // issuing access tokens, by IP
+	PolicyUser      = "user"      // private routes, by user, or by IP with the anonymous limit as well
+)
+
+// ForPolicy func for getting the limit of policy, unknown policies get the anonymous limit.
+func ForPolicy(cfg config.RateLimit, policy string) Limit {
+	switch policy {
+	case PolicyToken:
+		return PerMinute(policy, cfg.Token, cfg.TokenBurst)
+	case PolicyUser:
+		return PerMinute(policy, cfg.User, cfg.UserBurst)
+	default:
+		return PerMinute(policy, cfg.Anonymous, cfg.AnonymousBurst)
+	}
+}
+
+// ClientIP func for getting the IP of the client behind hops proxies, remoteIP is the one of the last proxy.
+// Each proxy appends the address it got the request from to X-Forwarded-For,
+// so entries before the last hops ones are set by the client and not trusted.
+func ClientIP(remoteIP, forwardedFor string, hops int) string {
+	if hops <= 0 {
+		return remoteIP
+	}
+
+	entries := strings.Split(forwardedFor, ",")
+	if len(entries) < hops {
+		return remoteIP
+	}
+	ip := strings.TrimSpace(entries[len(entries)-hops])
+	if net.ParseIP(ip) == nil {
+		return remoteIP
+	}
+
+	return ip
+}
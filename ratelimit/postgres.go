@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"opendavinci/database"
+)
+
+// expireAfter is how long unused buckets are kept in the database.
+const expireAfter = time.Hour
+
+// PostgresStore struct to describe buckets kept in the rate_limits table,
+// so all instances of the service share the same limits.
+type PostgresStore struct {
+	mu    sync.Mutex
+	swept time.Time
+}
+
+// NewPostgresStore func for creating a store in the shared database.
+func NewPostgresStore() *PostgresStore {
+	return &PostgresStore{swept: time.Now()}
+}
+
+// Take method for taking one token from the bucket of key in one statement.
+func (s *PostgresStore) Take(ctx context.Context, key string, l Limit) (Result, error) {
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+
+	tokens, allowed, err := db.TakeRateLimitToken(l.Name+":"+key, l.Rate, l.Burst)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if s.sweepDue() {
+		if err := db.DeleteRateLimits(expireAfter); err != nil {
+			return Result{}, err
+		}
+	}
+
+	return newResult(tokens, allowed, l), nil
+}
+
+// sweepDue reports, if this instance should remove unused buckets now.
+func (s *PostgresStore) sweepDue() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.swept) < sweepInterval {
+		return false
+	}
+	s.swept = time.Now()
+
+	return true
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit struct to describe a token bucket: Burst requests at once,
+// refilled with Rate tokens per second.
+type Limit struct {
+	Name  string // policy name, keys of different policies do not share buckets
+	Rate  float64
+	Burst int
+}
+
+// PerMinute func for creating a limit of n requests per minute with burst.
+func PerMinute(name string, n, burst int) Limit {
+	return Limit{Name: name, Rate: float64(n) / 60, Burst: burst}
+}
+
+// Result struct to describe the decision for one request.
+type Result struct {
+	Allowed    bool
+	Limit      int           // size of the bucket
+	Remaining  int           // requests allowed right now
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed, when not allowed
+}
+
+// Store interface to describe where buckets are kept.
+type Store interface {
+	// Take method for taking one token from the bucket of key.
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+}
+
+// refill func for getting tokens of a bucket after elapsed time.
+func refill(tokens float64, elapsed time.Duration, l Limit) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	return math.Min(float64(l.Burst), tokens+elapsed.Seconds()*l.Rate)
+}
+
+// take func for taking one token, it returns tokens left and the result.
+func take(tokens float64, l Limit) (float64, Result) {
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return tokens, newResult(tokens, allowed, l)
+}
+
+// newResult func for describing the bucket with tokens left.
+func newResult(tokens float64, allowed bool, l Limit) Result {
+	r := Result{
+		Allowed:   allowed,
+		Limit:     l.Burst,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     secondsUntil(float64(l.Burst)-tokens, l.Rate),
+	}
+	if !allowed {
+		r.RetryAfter = secondsUntil(1-tokens, l.Rate)
+	}
+
+	return r
+}
+
+func secondsUntil(missing, rate float64) time.Duration {
+	if missing <= 0 || rate <= 0 {
+		return 0
+	}
+
+	return time.Duration(missing / rate * float64(time.Second))
+}
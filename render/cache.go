@@ -0,0 +1,65 @@
+package render
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cacheSize is the number of rendered documents kept in memory.
+const cacheSize = 512
+
+// cache keeps recently rendered documents by revision.
+var cache = newLRU(cacheSize)
+
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key string
+	doc *Document
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *lru) get(key string) (*Document, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*lruEntry).doc, true
+}
+
+func (c *lru) put(key string, doc *Document) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToFront(e)
+		e.Value.(*lruEntry).doc = doc
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, doc: doc})
+
+	// Drop least recently used document.
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,180 @@
+package render
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// Content formats of lessons.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatText     = "text"
+)
+
+// Heading struct to describe an entry of the table of contents.
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Document struct to describe rendered lesson content.
+type Document struct {
+	Revision string    `json:"revision"`
+	HTML     string    `json:"html"`
+	TOC      []Heading `json:"toc"`
+}
+
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// policy allows user generated content plus heading anchors.
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(bluemonday.Paragraph).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("a", "code")
+	return p
+}()
+
+// Revision func for getting the revision of content in the given format.
+// Rendered documents are cached per revision.
+func Revision(format, content string) string {
+	sum := sha256.Sum256([]byte(format + "\x00" + content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Render func for rendering content to sanitized HTML.
+// Unknown formats are rendered as plain text.
+func Render(format, content string) (*Document, error) {
+	revision := Revision(format, content)
+	if doc, ok := cache.get(revision); ok {
+		return doc, nil
+	}
+
+	var doc *Document
+	var err error
+	switch format {
+	case FormatMarkdown:
+		doc, err = renderMarkdown(content)
+	case FormatHTML:
+		doc = renderHTML(content)
+	default:
+		doc = renderText(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.Revision = revision
+
+	cache.put(revision, doc)
+
+	return doc, nil
+}
+
+func renderMarkdown(content string) (*Document, error) {
+	source := []byte(content)
+	root := markdown.Parser().Parse(text.NewReader(source))
+
+	toc := []Heading{}
+	err := ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		id, _ := heading.AttributeString("id")
+		idBytes, _ := id.([]byte)
+
+		// Add anchor link, so readers can copy the link of a section.
+		anchor := ast.NewLink()
+		anchor.Destination = append([]byte("#"), idBytes...)
+		anchor.SetAttributeString("class", []byte("anchor"))
+		anchor.AppendChild(anchor, ast.NewString([]byte("#")))
+		heading.AppendChild(heading, anchor)
+
+		toc = append(toc, Heading{
+			Level: heading.Level,
+			ID:    string(idBytes),
+			Title: headingText(heading, source),
+		})
+
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := markdown.Renderer().Render(buf, source, root); err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		HTML: policy.Sanitize(buf.String()),
+		TOC:  toc,
+	}, nil
+}
+
+// renderHTML sanitizes imported HTML, it has no generated table of contents.
+func renderHTML(content string) *Document {
+	return &Document{
+		HTML: policy.Sanitize(content),
+		TOC:  []Heading{},
+	}
+}
+
+func renderText(content string) *Document {
+	buf := &strings.Builder{}
+
+	// Blank lines separate paragraphs, other line breaks are kept.
+	for _, p := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		buf.WriteString("<p>")
+		buf.WriteString(strings.ReplaceAll(html.EscapeString(p), "\n", "<br>\n"))
+		buf.WriteString("</p>\n")
+	}
+
+	return &Document{
+		HTML: buf.String(),
+		TOC:  []Heading{},
+	}
+}
+
+// headingText returns the plain text of heading, skipping the anchor link.
+func headingText(heading *ast.Heading, source []byte) string {
+	buf := &strings.Builder{}
+	_ = ast.Walk(heading, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Link:
+			if c, _ := t.AttributeString("class"); c != nil {
+				return ast.WalkSkipChildren, nil
+			}
+		case *ast.Text:
+			buf.Write(t.Segment.Value(source))
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return buf.String()
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/audit"
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/logging"
+)
+
+// Audit func for describe middleware setting the actor of the changes made by each request,
+// anonymous until JWTProtected verifies its access token. Queries with c.UserContext()
+// hand it to the audit triggers of the database.
+func Audit(c *fiber.Ctx) error {
+	c.SetUserContext(audit.WithActor(c.UserContext(), audit.Actor{
+		ID:        audit.Anonymous,
+		RequestID: logging.RequestID(c),
+		IP:        clientIP(c, config.Get().RateLimit.ProxyHops),
+	}))
+
+	return c.Next()
+}
+
+// authenticated func for setting the client of the verified access token as actor of the request.
+func authenticated(c *fiber.Ctx) error {
+	a, _ := audit.ActorFrom(c.UserContext())
+	a.ID = userKey(c)
+	c.SetUserContext(audit.WithActor(c.UserContext(), a))
+
+	return c.Next()
+}
+
+// recordRejectedToken func for recording an access token rejected for reason.
+// Requests without a token are not recorded, they are no auth event.
+// Failures are logged, the request gets 401 anyway.
+func recordRejectedToken(c *fiber.Ctx, reason string) {
+	db, err := database.OpenDBConnection(c.UserContext())
+	if err == nil {
+		err = db.CreateAuditEntry(audit.NewEntry(c.UserContext(), "auth.token_rejected", "token", "",
+			map[string]any{"reason": reason, "path": c.Path()}))
+	}
+	if err != nil {
+		logging.Ctx(c).Warn("Audit entry not recorded", "action", "auth.token_rejected", "error", err)
+	}
+}
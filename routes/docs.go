@@ -0,0 +1,123 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
+	swaggerFiles "github.com/swaggo/files/v2"
+
+	"opendavinci/openapi"
+	"opendavinci/problem"
+)
+
+// swaggerInitializer points the bundled Swagger UI to the document of the app.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// DocsRoutes func for describe routes of the API document:
+// OpenAPI 3.1 document at /openapi.json and Swagger UI at /docs.
+// Swagger UI is embedded in the binary, so it works without internet access.
+func DocsRoutes(a *fiber.App) {
+	// Routes for GET method:
+	a.Get("/openapi.json", func(c *fiber.Ctx) error {
+		spec, err := specOf(c.App())
+		if err != nil {
+			return err
+		}
+
+		c.Set(fiber.HeaderContentType, openapi.ContentType)
+		return c.Send(spec.json)
+	})
+	a.Get("/docs", func(c *fiber.Ctx) error {
+		return c.Redirect("/docs/index.html", fiber.StatusMovedPermanently)
+	})
+	a.Get("/docs/swagger-initializer.js", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJavaScriptCharsetUTF8)
+		return c.SendString(swaggerInitializer)
+	})
+	a.Use("/docs", filesystem.New(filesystem.Config{
+		Root:   http.FS(swaggerFiles.FS),
+		MaxAge: 86400,
+	}))
+}
+
+// apiSpec struct to describe the document of an app with its validator.
+type apiSpec struct {
+	doc       *openapi.Document
+	json      []byte
+	validator *openapi.Validator
+}
+
+var (
+	specsMu sync.Mutex
+	specs   = map[*fiber.App]*apiSpec{}
+)
+
+// specOf func for getting the document of app, it is generated on the first request,
+// when all routes are registered.
+func specOf(a *fiber.App) (*apiSpec, error) {
+	specsMu.Lock()
+	defer specsMu.Unlock()
+
+	if spec, ok := specs[a]; ok {
+		return spec, nil
+	}
+
+	doc := openapi.Generate(apiDocument(), a.GetRoutes(true))
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	spec := &apiSpec{doc: doc, json: data, validator: openapi.NewValidator(doc)}
+	specs[a] = spec
+
+	return spec, nil
+}
+
+// apiDocument func for describing the API besides operations of handlers.
+func apiDocument() openapi.Config {
+	return openapi.Config{
+		Info: openapi.Info{
+			Title:       "OpenDaVinci API",
+			Version:     "1.0.0",
+			Description: "Courses, lessons and their media. Errors are RFC 7807 problems with a stable code.",
+		},
+		Tags: []openapi.Tag{
+			{Name: "Course"}, {Name: "Lesson"}, {Name: "Media"}, {Name: "Token"},
+			{Name: "User", Description: "Users of API keys, visible to the user and admins"},
+			{Name: "Admin", Description: "Catalog import and export, routes below /admin need a token with the admin role"},
+			{Name: "Webhooks", Description: "Signed deliveries of catalog and enrollment events to partner systems"},
+			{Name: "Jobs", Description: "Background jobs with retries, dead jobs can be retried"},
+			{Name: "Audit", Description: "Append-only log of changes, auth events and admin actions"},
+			{Name: "Events", Description: "Change feed of Server-Sent Events"},
+			{Name: "Health", Description: "Probes of Cloud Run"},
+			{Name: "Legacy", Description: "Pre-v1 API, kept for old clients"},
+		},
+		Error:     problem.Problem{},
+		ErrorType: problem.ContentType,
+		Security: map[string]*openapi.SecurityScheme{
+			"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Access token from /api/v1/token/new"},
+		},
+		Middlewares: []openapi.Middleware{
+			{Name: "opendavinci/routes.JWTProtected", Security: "bearerAuth", Responses: []openapi.Response{
+				{Status: fiber.StatusUnauthorized, ContentType: problem.ContentType, Model: problem.Problem{}},
+			}},
+			{Name: "opendavinci/routes.RateLimit", Responses: []openapi.Response{
+				{Status: fiber.StatusTooManyRequests, ContentType: problem.ContentType, Model: problem.Problem{},
+					Headers: []string{"Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy"}},
+			}},
+		},
+	}
+}
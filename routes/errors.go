@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/config"
+	"opendavinci/logging"
+	"opendavinci/problem"
+)
+
+// legacyErrorsLocal marks requests answered with the pre-v1 error envelope.
+const legacyErrorsLocal = "legacyErrors"
+
+// ErrorHandler func for describe responses of errors returned by handlers and middlewares.
+// Errors are sent as RFC 7807 problems with a stable code and the request ID,
+// so callers can report them. Causes of errors are logged by the access log, but not sent.
+//
+// The legacy envelope {"error": true, "msg": ...} is sent instead for routes of the
+// pre-v1 API and when SERVER_ERROR_FORMAT is legacy, unless the client accepts
+// application/problem+json.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	p := problem.From(err)
+
+	// Copy, so problems shared by handlers are not changed.
+	resp := *p
+	resp.Instance = c.OriginalURL()
+	if i := strings.IndexByte(resp.Instance, '?'); i >= 0 {
+		resp.Instance = resp.Instance[:i]
+	}
+	resp.RequestID = logging.RequestID(c)
+
+	if legacyErrors(c) {
+		return c.Status(resp.Status).JSON(legacyEnvelope(&resp))
+	}
+
+	return c.Status(resp.Status).JSON(&resp, problem.ContentType)
+}
+
+// LegacyErrors middleware for sending errors of the route in the pre-v1 envelope.
+func LegacyErrors(c *fiber.Ctx) error {
+	c.Locals(legacyErrorsLocal, true)
+
+	return c.Next()
+}
+
+func legacyErrors(c *fiber.Ctx) bool {
+	if strings.Contains(c.Get(fiber.HeaderAccept), problem.ContentType) {
+		return false
+	}
+	if legacy, _ := c.Locals(legacyErrorsLocal).(bool); legacy {
+		return true
+	}
+
+	return config.Get().Server.ErrorFormat == "legacy"
+}
+
+// legacyEnvelope func for describing the problem in the pre-7807 envelope,
+// invalid fields are sent in msg like ValidatorErrors did.
+func legacyEnvelope(p *problem.Problem) fiber.Map {
+	var msg any = p.Detail
+	if len(p.Errors) > 0 {
+		msg = p.Errors
+	} else if p.Detail == "" {
+		msg = p.Title
+	}
+
+	body := fiber.Map{
+		"error":     true,
+		"msg":       msg,
+		"code":      p.Code,
+		"requestId": p.RequestID,
+	}
+	for key, value := range p.Extensions {
+		if _, ok := body[key]; !ok {
+			body[key] = value
+		}
+	}
+
+	return body
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"opendavinci/controllers"
+)
+
+// EventRoutes func for describe the route of the change feed, registered only when enabled in config.
+func EventRoutes(a *fiber.App) {
+	a.Get("/api/v1/events", RateLimit(PolicyAnonymous), controllers.GetEvents) // stream changes of courses and lessons
+}
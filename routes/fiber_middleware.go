@@ -3,7 +3,8 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+
+	"opendavinci/logging"
 )
 
 // FiberMiddleware provide Fiber's built-in middlewares.
@@ -12,7 +13,13 @@ func FiberMiddleware(a *fiber.App) {
 	a.Use(
 		// Add CORS to each route.
 		cors.New(),
-		// Add simple logger.
-		logger.New(),
+		// Add ID to each request, honour X-Request-ID of the caller.
+		RequestID,
+		// Set the actor of changes for the audit log.
+		Audit,
+		// Add access log in Cloud Logging format.
+		logging.Middleware,
+		// Log panics of handlers with their stack.
+		Recover,
 	)
 }
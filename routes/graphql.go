@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"opendavinci/controllers"
+)
+
+// GraphQLRoutes func for describe the GraphQL endpoint, registered only when enabled in config.
+func GraphQLRoutes(a *fiber.App) {
+	// Limit requests of each client by IP, private fields check the token themselves.
+	a.Post("/graphql", RateLimit(PolicyAnonymous), controllers.GraphQL) // run a GraphQL query
+}
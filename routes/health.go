@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/controllers"
+)
+
+// HealthRoutes func for describe routes of health probes.
+// Probes need no token and are registered before the access logger, so they are not logged.
+func HealthRoutes(a *fiber.App) {
+	// Routes for GET method:
+	a.Get("/healthz", controllers.Healthz)   // liveness
+	a.Get("/readyz", controllers.Readyz)     // readiness
+	a.Get("/startupz", controllers.Startupz) // startup, waits for migrations
+}
@@ -0,0 +1,197 @@
+package routes
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/jobs"
+	"opendavinci/logging"
+	"opendavinci/models"
+	"opendavinci/problem"
+)
+
+// Headers of idempotent requests.
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"     // sent by the client, unique per request it retries
+	HeaderIdempotentReplayed = "Idempotent-Replayed" // true, if the response was stored for an earlier request
+)
+
+// maxIdempotencyKeyLength limits idempotency keys sent by clients, UUIDs fit with room to spare.
+const maxIdempotencyKeyLength = 255
+
+// requestHeaders are response headers describing the request, not the stored response.
+// RateLimit-* headers are not replayed either.
+var requestHeaders = []string{"content-length", "date", "server", "connection", "x-request-id", "retry-after"}
+
+// Idempotent func for specify middleware replaying the first response to requests with
+// the same Idempotency-Key header of the user, so retries of POST requests are safe.
+// Private routes use it after JWTProtected. The response is stored with status, headers
+// and body until IDEMPOTENCY_TTL. A key reused with another method, URL or body gets 409,
+// as do retries while the first request is in progress. Server errors are not stored,
+// their retries are handled again.
+func Idempotent() func(*fiber.Ctx) error {
+	cfg := config.Get().Idempotency
+	if !cfg.Enabled {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+		if !validIdempotencyKey(key) {
+			// Return status 400 and invalid header error.
+			return problem.BadRequest(problem.CodeIdempotencyKeyInvalid, "Idempotency-Key must be 1 to 255 printable ASCII characters")
+		}
+
+		// Create database connection.
+		db, err := database.OpenDBConnection(c.UserContext())
+		if err != nil {
+			// Return status 503 and database connection error.
+			return problem.From(err)
+		}
+
+		now := time.Now()
+		k := &models.IdempotencyKey{
+			UserKey:     userKey(c),
+			Key:         key,
+			Fingerprint: fingerprint(c),
+			LockedUntil: now.Add(cfg.Lease),
+			Expires:     now.Add(cfg.TTL),
+		}
+		claimed, err := db.ClaimIdempotencyKey(k)
+		if err != nil {
+			// Return status 500 and database error.
+			return problem.From(err)
+		}
+		if !claimed {
+			return replay(c, db, k)
+		}
+
+		return storeResponse(c, db, k)
+	}
+}
+
+// storeResponse func for handling the first request of key k and storing its response.
+func storeResponse(c *fiber.Ctx, db *database.Queries, k *models.IdempotencyKey) error {
+	err := c.Next()
+
+	// Errors are turned into responses by the error handler after the middleware.
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = problem.From(err).Status
+	}
+	if status >= fiber.StatusInternalServerError || c.Response().IsBodyStream() {
+		if err := db.ReleaseIdempotencyKey(k); err != nil {
+			logging.Ctx(c).Warn("Idempotency key not released", "error", err)
+		}
+		return err
+	}
+
+	// Send the problem now, so it is stored like any other response.
+	// It is handled then, the error handler must not run again for it.
+	if err != nil {
+		if herr := c.App().ErrorHandler(c, err); herr != nil {
+			return herr
+		}
+	}
+
+	k.Status = c.Response().StatusCode()
+	k.Headers = models.Headers{}
+	c.Response().Header.VisitAll(func(name, value []byte) {
+		n := strings.ToLower(string(name))
+		if !slices.Contains(requestHeaders, n) && !strings.HasPrefix(n, "ratelimit-") {
+			k.Headers[string(name)] = string(value)
+		}
+	})
+	k.Body = slices.Clone(c.Response().Body())
+
+	if err := db.SaveIdempotentResponse(k); err != nil {
+		// Retries get 409 until the lease of the key ends, then they are handled again.
+		logging.Ctx(c).Warn("Idempotent response not stored", "error", err)
+	}
+
+	return nil
+}
+
+// replay func for answering a retry of key k with the stored response.
+func replay(c *fiber.Ctx, db *database.Queries, k *models.IdempotencyKey) error {
+	stored, err := db.GetIdempotencyKey(k.UserKey, k.Key)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		// Return status 500 and database error.
+		return problem.From(err)
+	}
+
+	switch {
+	case err == nil && stored.Fingerprint != k.Fingerprint:
+		// Return status 409 and reused key error.
+		return problem.New(fiber.StatusConflict, problem.CodeIdempotencyKeyReused,
+			"Idempotency-Key was used for a request with another method, URL or body")
+	case err != nil, stored.Status == 0:
+		// The first request is in progress, or it failed and its key was released just now.
+		c.Set(fiber.HeaderRetryAfter, "1")
+		// Return status 409 and in progress error.
+		return problem.New(fiber.StatusConflict, problem.CodeIdempotencyInProgress,
+			"a request with this Idempotency-Key is in progress, retry later")
+	}
+
+	for name, value := range stored.Headers {
+		c.Set(name, value)
+	}
+	c.Set(HeaderIdempotentReplayed, "true")
+
+	return c.Status(stored.Status).Send(stored.Body)
+}
+
+// fingerprint func for hashing method, URL and body of the request,
+// retries must send the same ones with the same key.
+func fingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+	h.Write(c.Body())
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// idempotencySweepArgs struct to describe the periodic job deleting expired idempotency keys.
+type idempotencySweepArgs struct{}
+
+func (idempotencySweepArgs) Kind() string { return "idempotency.sweep" }
+
+// RegisterIdempotencySweep func for deleting expired idempotency keys by the job worker
+// of this process, before it is started.
+func RegisterIdempotencySweep() {
+	jobs.Register(func(ctx context.Context, _ *jobs.Job[idempotencySweepArgs]) error {
+		db, err := database.OpenDBConnection(ctx)
+		if err != nil {
+			return err
+		}
+		return db.DeleteIdempotencyKeys(time.Now())
+	}, jobs.Options{MaxAttempts: 1})
+	jobs.Periodic(idempotencySweepArgs{}, time.Hour)
+}
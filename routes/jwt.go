@@ -1,38 +1,116 @@
 package routes
 
 import (
-	"os"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4" // version used by the middleware
 
 	jwtMiddleware "github.com/gofiber/jwt/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"opendavinci/config"
+	"opendavinci/metrics"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/tracing"
 )
 
+// jwtSpanLocal is the key of the jwt.verify span in the locals of a request.
+const jwtSpanLocal = "jwtSpan"
+
 // JWTProtected func for specify routes group with JWT authentication.
+// Verifying the token is traced as jwt.verify, a child span of the request.
 // See: https://github.com/gofiber/jwt
 func JWTProtected() func(*fiber.Ctx) error {
 	// Create config for JWT authentication middleware.
+	// Its handlers run the rest of the request, the span ends before.
 	config := jwtMiddleware.Config{
-		SigningKey:   []byte(os.Getenv("JWT_SECRET_KEY")),
-		ContextKey:   "jwt", // used in private routes
-		ErrorHandler: jwtError,
+		SigningKey: []byte(config.Get().JWT.SecretKey),
+		ContextKey: "jwt", // used in private routes
+		SuccessHandler: func(c *fiber.Ctx) error {
+			endJWTSpan(c, nil)
+			return authenticated(c)
+		},
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			endJWTSpan(c, err)
+			return jwtError(c, err)
+		},
+	}
+	verify := jwtMiddleware.New(config)
+
+	return func(c *fiber.Ctx) error {
+		_, span := tracing.Tracer().Start(c.UserContext(), "jwt.verify")
+		c.Locals(jwtSpanLocal, span)
+
+		return verify(c)
 	}
+}
 
-	return jwtMiddleware.New(config)
+// endJWTSpan func for ending the jwt.verify span of the request, a rejected token marks it as failed.
+func endJWTSpan(c *fiber.Ctx, err error) {
+	span, ok := c.Locals(jwtSpanLocal).(trace.Span)
+	if !ok {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, jwtFailureReason(err))
+	}
+	span.End()
+	c.Locals(jwtSpanLocal, nil)
+}
+
+// AdminOnly func for specify middleware of admin routes, used after JWTProtected.
+// The access token must have the admin role, see GetNewAccessToken, others get 403.
+func AdminOnly(c *fiber.Ctx) error {
+	token, ok := c.Locals("jwt").(*jwt.Token)
+	if !ok {
+		// Return status 401 and missing token error.
+		return problem.Unauthorized(problem.CodeTokenMissing, "missing or malformed access token")
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	if role, _ := claims["role"].(string); role != models.RoleAdmin {
+		// Return status 403 and forbidden error.
+		return problem.New(fiber.StatusForbidden, problem.CodeForbidden, "access token must have the admin role")
+	}
+
+	return c.Next()
 }
 
 func jwtError(c *fiber.Ctx, err error) error {
-	// Return status 401 and failed authentication error.
+	reason := jwtFailureReason(err)
+	metrics.JWTFailures.WithLabelValues(reason).Inc()
+
+	// Return status 401 and missing token error.
 	if err.Error() == "Missing or malformed JWT" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+		return problem.Wrap(err, fiber.StatusUnauthorized, problem.CodeTokenMissing, "missing or malformed access token")
 	}
 
+	recordRejectedToken(c, reason)
+
 	// Return status 401 and failed authentication error.
-	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-		"error": true,
-		"msg":   err.Error(),
-	})
+	return problem.From(err)
+}
+
+// jwtFailureReason func for getting the metric label of rejected token.
+func jwtFailureReason(err error) string {
+	if err.Error() == "Missing or malformed JWT" {
+		return "malformed"
+	}
+
+	var verr *jwt.ValidationError
+	if errors.As(err, &verr) {
+		switch {
+		case verr.Errors&jwt.ValidationErrorExpired != 0:
+			return "expired"
+		case verr.Errors&jwt.ValidationErrorMalformed != 0:
+			return "malformed"
+		case verr.Errors&jwt.ValidationErrorSignatureInvalid != 0:
+			return "signature"
+		}
+	}
+
+	return "invalid"
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"opendavinci/controllers"
+)
+
+// LegacyRoutes func for describe group of routes of the pre-v1 API.
+// They are kept for old clients and registered only when enabled in config.
+func LegacyRoutes(a *fiber.App) {
+	// Create routes group, errors are sent in the envelope old clients expect.
+	route := a.Group("/api")
+
+	// Limit requests of each client by IP.
+	anonymous := RateLimit(PolicyAnonymous)
+
+	// Routes for GET method:
+	route.Get("/courses", LegacyErrors, anonymous, controllers.LegacyGetCourses) // get list of all courses
+	route.Get("/lessons", LegacyErrors, anonymous, controllers.LegacyGetLessons) // show request headers
+
+	// Routes for POST method:
+	route.Post("/courses", LegacyErrors, anonymous, controllers.LegacyCreateCourse) // create a new course from raw JSON
+}
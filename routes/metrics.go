@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/metrics"
+)
+
+// MetricsRoutes func for describe route of Prometheus metrics.
+// Registered before the access logger, scrapes are not logged.
+func MetricsRoutes(a *fiber.App) {
+	// Routes for GET method:
+	a.Get("/metrics", metrics.Handler())
+}
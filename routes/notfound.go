@@ -1,6 +1,10 @@
 package routes
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/problem"
+)
 
 // NotFoundRoute func for describe 404 Error route.
 func NotFoundRoute(a *fiber.App) {
@@ -8,11 +12,8 @@ func NotFoundRoute(a *fiber.App) {
 	a.Use(
 		// Anonymous function.
 		func(c *fiber.Ctx) error {
-			// Return HTTP 404 status and JSON response.
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": true,
-				"msg":   "sorry, endpoint is not found",
-			})
+			// Return HTTP 404 status and problem response.
+			return problem.NotFound(problem.CodeRouteNotFound, "sorry, endpoint is not found")
 		},
 	)
 }
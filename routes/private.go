@@ -10,12 +10,41 @@ func PrivateRoutes(a *fiber.App) {
 	// Create routes group.
 	route := a.Group("/api/v1")
 
+	// Limit requests of each client by user, or by IP for tokens without user.
+	user := RateLimit(PolicyUser)
+
+	// Replay the first response to retries with the same Idempotency-Key.
+	idempotent := Idempotent()
+
 	// Routes for POST method:
-	route.Post("/course", JWTProtected(), controllers.CreateCourse) // create a new course
+	route.Post("/course", JWTProtected(), user, idempotent, controllers.CreateCourse)                                                         // create a new course
+	route.Post("/course/:id/image", JWTProtected(), user, idempotent, controllers.UploadCourseImage)                                          // upload image of course
+	route.Post("/lesson/:id/attachment", JWTProtected(), user, idempotent, controllers.UploadLessonAttachment)                                // upload attachment of lesson
+	route.Post("/admin/import", JWTProtected(), user, AdminOnly, idempotent, controllers.ImportCatalog)                                       // import courses with lessons
+	route.Post("/admin/import/package", JWTProtected(), user, AdminOnly, idempotent, controllers.ImportPackage)                               // import Common Cartridge or SCORM package
+	route.Post("/admin/webhooks", JWTProtected(), user, AdminOnly, idempotent, controllers.CreateWebhook)                                     // create a new webhook
+	route.Post("/admin/webhooks/:id/ping", JWTProtected(), user, AdminOnly, idempotent, controllers.PingWebhook)                              // send a ping event to webhook
+	route.Post("/admin/webhooks/deliveries/:id/redeliver", JWTProtected(), user, AdminOnly, idempotent, controllers.RedeliverWebhookDelivery) // queue the event of a delivery again
+	route.Post("/admin/jobs/:id/retry", JWTProtected(), user, AdminOnly, idempotent, controllers.RetryJob)                                    // queue a dead job again
+
+	// Routes for GET method:
+	route.Get("/user/me", JWTProtected(), user, controllers.GetCurrentUser)                                        // get the user of the access token
+	route.Get("/user/:id", JWTProtected(), user, controllers.GetUser)                                              // get one user by ID, for the user and admins
+	route.Get("/admin/export", JWTProtected(), user, AdminOnly, controllers.ExportCatalog)                         // export courses with lessons
+	route.Get("/admin/webhooks", JWTProtected(), user, AdminOnly, controllers.GetWebhooks)                         // get all webhooks
+	route.Get("/admin/webhooks/deliveries/:id", JWTProtected(), user, AdminOnly, controllers.GetWebhookDelivery)   // get one delivery with attempts
+	route.Get("/admin/webhooks/:id", JWTProtected(), user, AdminOnly, controllers.GetWebhook)                      // get one webhook by ID
+	route.Get("/admin/webhooks/:id/deliveries", JWTProtected(), user, AdminOnly, controllers.GetWebhookDeliveries) // get delivery history of webhook
+	route.Get("/admin/jobs", JWTProtected(), user, AdminOnly, controllers.GetJobs)                                 // get jobs, dead ones by default
+	route.Get("/admin/jobs/:id", JWTProtected(), user, AdminOnly, controllers.GetJob)                              // get one job by ID
+	route.Get("/admin/audit", JWTProtected(), user, AdminOnly, controllers.GetAuditLog)                            // get audit log by actor, resource and time
+	route.Get("/admin/audit/verify", JWTProtected(), user, AdminOnly, controllers.VerifyAuditLog)                  // verify hash chain of audit log
 
 	// Routes for PUT method:
-	route.Put("/course", JWTProtected(), controllers.UpdateCourse) // update one course by ID
+	route.Put("/course", JWTProtected(), user, controllers.UpdateCourse)                         // update one course by ID
+	route.Put("/admin/webhooks/:id", JWTProtected(), user, AdminOnly, controllers.UpdateWebhook) // update one webhook by ID
 
 	// Routes for DELETE method:
-	////route.Delete("/course", JWTProtected(), controllers.DeleteCourse) // delete one course by ID
+	////route.Delete("/course", JWTProtected(), user, controllers.DeleteCourse) // delete one course by ID
+	route.Delete("/admin/webhooks/:id", JWTProtected(), user, AdminOnly, controllers.DeleteWebhook) // delete one webhook by ID
 }
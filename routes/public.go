@@ -10,8 +10,15 @@ func PublicRoutes(a *fiber.App) {
 	// Create routes group.
 	route := a.Group("/api/v1")
 
+	// Limit requests of each client by IP.
+	anonymous := RateLimit(PolicyAnonymous)
+
 	// Routes for GET method:
-	route.Get("/courses", controllers.GetCourses)          // get list of all courses
-	route.Get("/course/:id", controllers.GetCourse)        // get one course by ID
-	route.Get("/token/new", controllers.GetNewAccessToken) // create a new access tokens
+	route.Get("/courses", anonymous, controllers.GetCourses)                              // get list of all courses
+	route.Get("/course/:id", anonymous, controllers.GetCourse)                            // get one course by ID
+	route.Get("/course/:id/image/:variant", anonymous, controllers.GetCourseImageVariant) // get resized image of course
+	route.Get("/lesson/:id", anonymous, controllers.GetLesson)                            // get one lesson by ID
+	route.Get("/lesson/:id/html", anonymous, controllers.GetLessonHTML)                   // get rendered content of lesson
+	route.Get("/token/new", RateLimit(PolicyToken), controllers.GetNewAccessToken)        // create a new access tokens
+	route.Get("/media/*", anonymous, controllers.GetMedia)                                // download media by signed URL
 }
@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+
+	"opendavinci/audit"
+	"opendavinci/config"
+	"opendavinci/logging"
+	"opendavinci/metrics"
+	"opendavinci/problem"
+	"opendavinci/ratelimit"
+)
+
+// Rate limit policies of routes, shared with the gRPC services.
+const (
+	PolicyAnonymous = ratelimit.PolicyAnonymous
+	PolicyToken     = ratelimit.PolicyToken
+	PolicyUser      = ratelimit.PolicyUser
+)
+
+// RateLimit func for specify middleware limiting requests of the policy
+// with a token bucket per client. Private routes use it after JWTProtected.
+// Responses get RateLimit-* headers, rejected requests get 429 with Retry-After.
+// When the store fails, requests are let through and the error is logged.
+func RateLimit(policy string) func(*fiber.Ctx) error {
+	cfg := config.Get().RateLimit
+	if !cfg.Enabled {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+
+	limit := ratelimit.ForPolicy(cfg, policy)
+	ipLimit := ratelimit.ForPolicy(cfg, PolicyAnonymous)
+
+	return func(c *fiber.Ctx) error {
+		store, err := ratelimit.OpenStore()
+		if err != nil {
+			return err
+		}
+
+		key := clientIP(c, cfg.ProxyHops)
+		if policy == PolicyUser {
+			key = limitKey(c, key)
+			if !strings.HasPrefix(key, "user:") {
+				// Tokens without user are free to mint, their clients are limited by IP as anonymous ones.
+				if err := take(c, store, key, ipLimit); err != nil {
+					return err
+				}
+			}
+		}
+		if err := take(c, store, key, limit); err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
+
+// take func for taking one token from the bucket of key and setting the RateLimit-* headers.
+// It returns the problem of a rejected request.
+func take(c *fiber.Ctx, store ratelimit.Store, key string, limit ratelimit.Limit) error {
+	res, err := store.Take(c.UserContext(), key, limit)
+	if err != nil {
+		metrics.RateLimitErrors.WithLabelValues(limit.Name).Inc()
+		logging.Ctx(c).Warn("Rate limit not checked", "policy", limit.Name, "error", err)
+		return nil
+	}
+
+	perMinute := int(math.Round(limit.Rate * 60))
+	c.Set("RateLimit-Policy", strconv.Itoa(perMinute)+";w=60;burst="+strconv.Itoa(limit.Burst))
+	c.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Set("RateLimit-Reset", seconds(res.Reset))
+
+	if !res.Allowed {
+		metrics.RateLimited.WithLabelValues(limit.Name).Inc()
+		c.Set(fiber.HeaderRetryAfter, seconds(res.RetryAfter))
+		return problem.New(fiber.StatusTooManyRequests, problem.CodeRateLimited,
+			"too many requests, retry in "+seconds(res.RetryAfter)+" seconds")
+	}
+
+	return nil
+}
+
+// clientIP func for getting the IP of the client behind hops proxies.
+func clientIP(c *fiber.Ctx, hops int) string {
+	return ratelimit.ClientIP(c.IP(), c.Get(fiber.HeaderXForwardedFor), hops)
+}
+
+// userKey func for getting the client of an authenticated request:
+// the user of the token, or the token itself when it has no subject.
+func userKey(c *fiber.Ctx) string {
+	raw := ""
+	if token, ok := c.Locals("jwt").(*jwt.Token); ok {
+		raw = token.Raw
+	}
+
+	return audit.ActorID(logging.UserID(c), raw)
+}
+
+// limitKey func for getting the client of an authenticated request by its rate limit:
+// the user of the token, or the IP when the token has no subject, a new token must not reset the limit.
+func limitKey(c *fiber.Ctx, ip string) string {
+	if sub := logging.UserID(c); sub != "" {
+		return audit.ActorID(sub, "")
+	}
+
+	return ip
+}
+
+// seconds func for header values, rounded up so clients do not retry too early.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
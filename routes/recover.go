@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/logging"
+)
+
+// Recover func for describe middleware turning panics of handlers into 500 errors.
+// The stack is logged with the request ID, the caller only gets the ID.
+func Recover(c *fiber.Ctx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Ctx(c).ErrorContext(c.UserContext(), "Handler panic",
+				"panic", fmt.Sprint(r),
+				"stack", string(debug.Stack()),
+			)
+			err = fiber.ErrInternalServerError
+		}
+	}()
+
+	return c.Next()
+}
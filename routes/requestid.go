@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"opendavinci/logging"
+)
+
+// RequestID func for describe middleware setting ID of each request.
+// X-Request-ID of the caller is kept, if it is safe to log; otherwise a new UUID is set.
+// The ID is returned in X-Request-ID of the response.
+func RequestID(c *fiber.Ctx) error {
+	id := c.Get(fiber.HeaderXRequestID)
+	if !logging.ValidRequestID(id) {
+		id = uuid.NewString()
+	}
+
+	c.Locals(logging.RequestIDLocal, id)
+	c.Set(fiber.HeaderXRequestID, id)
+
+	return c.Next()
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+
+	"opendavinci/logging"
+	"opendavinci/rpc"
+)
+
+// RPCRoutes func for describe routes of the gRPC services for Connect and gRPC-Web clients,
+// e.g. POST /opendavinci.v1.CourseService/GetCourse. gRPC itself needs HTTP/2, see app.Run.
+// Calls are rate limited by the services, with the buckets of the policies of routes.
+func RPCRoutes(a *fiber.App) {
+	prefixes, h := rpc.Handler()
+	handler := adaptor.HTTPHandler(h)
+
+	for _, prefix := range prefixes {
+		a.All(prefix+"*", func(c *fiber.Ctx) error {
+			// The services get the request ID from the request, like the h2c server sets it.
+			c.Request().Header.Set(fiber.HeaderXRequestID, logging.RequestID(c))
+			return handler(c)
+		})
+	}
+}
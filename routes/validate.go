@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"opendavinci/logging"
+	"opendavinci/openapi"
+	"opendavinci/problem"
+)
+
+// ValidateRequests func for specify middleware checking requests against the OpenAPI document.
+// Invalid path and query parameters and JSON bodies are rejected with 400 before handlers run,
+// errors are keyed by JSON pointers like /body/title or /query/format.
+//
+// With responses set (development mode), JSON responses are checked as well and
+// a response which does not match the document is replaced by 500, so drift fails loudly in tests.
+func ValidateRequests(responses bool) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		spec, err := specOf(c.App())
+		if err != nil {
+			return err
+		}
+
+		op, params, ok := spec.validator.Find(c.Method(), c.Path())
+		if !ok {
+			return c.Next()
+		}
+
+		query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+		errs := spec.validator.ValidateRequest(op, params, openapi.Request{
+			Method:      c.Method(),
+			Path:        c.Path(),
+			Query:       query,
+			ContentType: c.Get(fiber.HeaderContentType),
+			Body:        c.Body(),
+		})
+		if len(errs) > 0 {
+			// Return status 400 and invalid locations.
+			return invalid(fiber.StatusBadRequest, problem.CodeValidationFailed, "invalid request: ", errs)
+		}
+
+		if !responses {
+			return c.Next()
+		}
+
+		// Errors are sent by the error handler, they are problems of the default response.
+		if err := c.Next(); err != nil {
+			return err
+		}
+		resp := c.Response()
+		if resp.IsBodyStream() {
+			return nil
+		}
+
+		errs = spec.validator.ValidateResponse(op, resp.StatusCode(), string(resp.Header.ContentType()), resp.Body())
+		if len(errs) > 0 {
+			logging.Ctx(c).Error("Response does not match the API document",
+				"operation", op.OperationID, "error", errs)
+			resp.ResetBody()
+			// Return status 500 and locations, which drifted from the document.
+			return invalid(fiber.StatusInternalServerError, problem.CodeInternal, "response does not match the API document: ", errs)
+		}
+
+		return nil
+	}
+}
+
+// invalid func for a problem listing locations which do not match the document.
+func invalid(status int, code, detail string, errs openapi.Errors) *problem.Problem {
+	p := problem.New(status, code, detail+strings.Join(errs.Locations(), ", "))
+	p.Errors = errs
+
+	return p
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt"
+
+	"opendavinci/audit"
+	"opendavinci/config"
+	"opendavinci/controllers"
+	"opendavinci/database"
+	"opendavinci/logging"
+	"opendavinci/metrics"
+	"opendavinci/problem"
+	"opendavinci/ratelimit"
+)
+
+// authorize func for checking the access token in the authorization metadata, "Bearer <token>",
+// like private routes of the REST API do with the Authorization header.
+// The returned ctx carries the client of the token as actor of the changes made with it.
+func authorize(ctx context.Context, req connect.AnyRequest) (context.Context, error) {
+	actor := audit.Actor{
+		ID:        audit.Anonymous,
+		RequestID: req.Header().Get("X-Request-ID"),
+		IP:        peerIP(req),
+	}
+	ctx = audit.WithActor(ctx, actor)
+
+	// Get claims from JWT.
+	token := controllers.BearerToken(req.Header().Get("Authorization"))
+	claims, err := controllers.ParseTokenMetadata(token)
+	if err != nil {
+		if token == "" {
+			metrics.JWTFailures.WithLabelValues("malformed").Inc()
+		} else {
+			recordRejectedToken(ctx, req, tokenFailureReason(err))
+		}
+		return ctx, err
+	}
+
+	// Checking, if now time greater than expiration from JWT.
+	if time.Now().Unix() > claims.Expires {
+		recordRejectedToken(ctx, req, "expired")
+		return ctx, problem.Unauthorized(problem.CodeTokenExpired, "unauthorized, check expiration time of your token")
+	}
+
+	actor.ID = audit.ActorID(claims.Subject, token)
+
+	return audit.WithActor(ctx, actor), nil
+}
+
+// recordRejectedToken func for counting and recording an access token rejected for reason,
+// like JWTProtected does for routes. Failures are logged, the call is rejected anyway.
+func recordRejectedToken(ctx context.Context, req connect.AnyRequest, reason string) {
+	metrics.JWTFailures.WithLabelValues(reason).Inc()
+
+	db, err := database.OpenDBConnection(ctx)
+	if err == nil {
+		err = db.CreateAuditEntry(audit.NewEntry(ctx, "auth.token_rejected", "token", "",
+			map[string]any{"reason": reason, "procedure": req.Spec().Procedure}))
+	}
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "Audit entry not recorded", "action", "auth.token_rejected", "error", err)
+	}
+}
+
+// tokenFailureReason func for getting the metric label of rejected token.
+func tokenFailureReason(err error) string {
+	var verr *jwt.ValidationError
+	if errors.As(err, &verr) {
+		switch {
+		case verr.Errors&jwt.ValidationErrorExpired != 0:
+			return "expired"
+		case verr.Errors&jwt.ValidationErrorMalformed != 0:
+			return "malformed"
+		case verr.Errors&jwt.ValidationErrorSignatureInvalid != 0:
+			return "signature"
+		}
+	}
+
+	return "invalid"
+}
+
+// peerIP func for getting the IP of the client of req behind the proxies of the rate limit config.
+func peerIP(req connect.AnyRequest) string {
+	ip, _, err := net.SplitHostPort(req.Peer().Addr)
+	if err != nil {
+		ip = req.Peer().Addr
+	}
+
+	return ratelimit.ClientIP(ip, req.Header().Get("X-Forwarded-For"), config.Get().RateLimit.ProxyHops)
+}
@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"opendavinci/controllers"
+	"opendavinci/database"
+	opendavinciv1 "opendavinci/gen/opendavinci/v1"
+	"opendavinci/metrics"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/queries"
+)
+
+// maxCoursesPerPage is the largest limit of ListCourses, like of GET /api/v1/courses.
+const maxCoursesPerPage = 100
+
+// CourseServer struct to implement CourseService like the course controllers.
+type CourseServer struct{}
+
+// ListCourses method gets all courses, or a page of them with limit.
+func (CourseServer) ListCourses(ctx context.Context, req *connect.Request[opendavinciv1.ListCoursesRequest]) (*connect.Response[opendavinciv1.ListCoursesResponse], error) {
+	limit := int(req.Msg.GetLimit())
+	if limit < 0 || limit > maxCoursesPerPage {
+		return nil, problem.BadRequest(problem.CodeInvalidQuery, fmt.Sprintf("limit must be from 0 to %d", maxCoursesPerPage))
+	}
+	after, err := queries.ParseCourseCursor(req.Msg.GetCursor())
+	if err != nil {
+		return nil, problem.BadRequest(problem.CodeInvalidQuery, "cursor is malformed")
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get all courses, or one more than the page to know if there is a next one.
+	var courses []models.Course
+	if limit == 0 {
+		courses, err = db.GetCourses()
+	} else {
+		courses, err = db.GetCoursesAfter(after, limit+1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &opendavinciv1.ListCoursesResponse{}
+	if limit > 0 && len(courses) > limit {
+		courses = courses[:limit]
+		res.NextCursor = queries.CourseCursorOf(courses[limit-1]).String()
+	}
+	for _, course := range courses {
+		res.Courses = append(res.Courses, courseMessage(ctx, course))
+	}
+
+	return connect.NewResponse(res), nil
+}
+
+// GetCourse method gets course by given ID.
+func (CourseServer) GetCourse(ctx context.Context, req *connect.Request[opendavinciv1.GetCourseRequest]) (*connect.Response[opendavinciv1.GetCourseResponse], error) {
+	id, err := uuid.Parse(req.Msg.GetId())
+	if err != nil {
+		return nil, problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get course by ID.
+	course, err := db.GetCourse(id)
+	if err != nil {
+		return nil, problem.Lookup(err, problem.CodeCourseNotFound, "course with the given ID is not found")
+	}
+
+	return connect.NewResponse(&opendavinciv1.GetCourseResponse{Course: courseMessage(ctx, course)}), nil
+}
+
+// CreateCourse method creates a new course, it needs an access token.
+func (CourseServer) CreateCourse(ctx context.Context, req *connect.Request[opendavinciv1.CreateCourseRequest]) (*connect.Response[opendavinciv1.CreateCourseResponse], error) {
+	ctx, err := authorize(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	course := courseModel(req.Msg.GetCourse())
+
+	// Set initialized default data for course.
+	course.ID = uuid.New()
+	course.Created = time.Now()
+
+	// Validate course fields.
+	if err := controllers.NewValidator().Struct(course); err != nil {
+		return nil, problem.Validation(err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.CreateCourse(course); err != nil {
+		return nil, err
+	}
+	metrics.CoursesCreated.Inc()
+
+	return connect.NewResponse(&opendavinciv1.CreateCourseResponse{Course: courseMessage(ctx, *course)}), nil
+}
+
+// UpdateCourse method updates the course with the ID of the given one, it needs an access token.
+func (CourseServer) UpdateCourse(ctx context.Context, req *connect.Request[opendavinciv1.UpdateCourseRequest]) (*connect.Response[opendavinciv1.UpdateCourseResponse], error) {
+	ctx, err := authorize(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := req.Msg.GetCourse()
+	id, err := uuid.Parse(msg.GetId())
+	if err != nil {
+		return nil, problem.InvalidID("course.id", err)
+	}
+	course := courseModel(msg)
+	course.ID = id
+
+	// Validate course fields.
+	if err := controllers.NewValidator().Struct(course); err != nil {
+		return nil, problem.Validation(err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checking, if course with given ID does exist.
+	found, err := db.GetCourse(course.ID)
+	if err != nil {
+		return nil, problem.Lookup(err, problem.CodeCourseNotFound, "course with this ID not found")
+	}
+
+	// Update course by given ID.
+	if err := db.UpdateCourse(found.ID, course); err != nil {
+		return nil, err
+	}
+	metrics.CoursesUpdated.Inc()
+
+	return connect.NewResponse(&opendavinciv1.UpdateCourseResponse{}), nil
+}
+
+// courseMessage func for the message of course, with signed image URLs.
+func courseMessage(ctx context.Context, course models.Course) *opendavinciv1.Course {
+	controllers.SignCourseImage(ctx, &course)
+
+	return &opendavinciv1.Course{
+		Id:            course.ID.String(),
+		Created:       timestamppb.New(course.Created),
+		CourseId:      course.CourseID,
+		Title:         course.Title,
+		Instructor:    course.Instructor,
+		Description:   course.Descriptions,
+		Subject:       course.Subject,
+		Image:         course.Image,
+		ImageUrl:      course.ImageURL,
+		ImageVariants: course.ImageVariants,
+		Published:     course.Published,
+		Updated:       course.Updated,
+	}
+}
+
+// courseModel func for the model of a course message, ID and created are set by the caller.
+func courseModel(msg *opendavinciv1.Course) *models.Course {
+	return &models.Course{
+		CourseID:     msg.GetCourseId(),
+		Title:        msg.GetTitle(),
+		Instructor:   msg.GetInstructor(),
+		Descriptions: msg.GetDescription(),
+		Subject:      msg.GetSubject(),
+		Image:        msg.GetImage(),
+		Published:    msg.GetPublished(),
+		Updated:      msg.GetUpdated(),
+	}
+}
@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"opendavinci/logging"
+	"opendavinci/problem"
+)
+
+// errorDomain is the domain of ErrorInfo details, their reason is the problem code.
+const errorDomain = "opendavinci"
+
+// problemInterceptor func for sending problems returned by handlers as errors of the protocol,
+// like the error handler of the app does for routes.
+// The status maps to the code, the stable code and invalid fields are sent as ErrorInfo.
+func problemInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			res, err := next(ctx, req)
+			if err == nil {
+				return res, nil
+			}
+
+			var cerr *connect.Error
+			if errors.As(err, &cerr) {
+				return nil, err
+			}
+
+			p := problem.From(err)
+			if p.Status >= http.StatusInternalServerError {
+				// Causes are logged, but not sent.
+				logging.FromContext(ctx).ErrorContext(ctx, "RPC failed", "procedure", req.Spec().Procedure, "error", p)
+			}
+
+			return nil, errorOf(p)
+		}
+	}
+}
+
+// errorOf func for the error of problem p.
+func errorOf(p *problem.Problem) *connect.Error {
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+
+	cerr := connect.NewError(codeOf(p.Status), errors.New(msg))
+	if detail, err := connect.NewErrorDetail(&errdetails.ErrorInfo{
+		Reason:   p.Code,
+		Domain:   errorDomain,
+		Metadata: p.Errors,
+	}); err == nil {
+		cerr.AddDetail(detail)
+	}
+
+	return cerr
+}
+
+// codeOf func for the code of HTTP status, the reverse of the mapping of gRPC-Gateway.
+func codeOf(status int) connect.Code {
+	switch status {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType:
+		return connect.CodeInvalidArgument
+	case http.StatusUnauthorized:
+		return connect.CodeUnauthenticated
+	case http.StatusForbidden:
+		return connect.CodePermissionDenied
+	case http.StatusNotFound:
+		return connect.CodeNotFound
+	case http.StatusConflict:
+		return connect.CodeAlreadyExists
+	case http.StatusTooManyRequests:
+		return connect.CodeResourceExhausted
+	case http.StatusServiceUnavailable:
+		return connect.CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return connect.CodeDeadlineExceeded
+	}
+
+	return connect.CodeInternal
+}
+
+// statusOf func for the HTTP status of code, the reverse of codeOf.
+func statusOf(code connect.Code) int {
+	switch code {
+	case connect.CodeInvalidArgument:
+		return http.StatusBadRequest
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists:
+		return http.StatusConflict
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case connect.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusInternalServerError
+}
+
+// recoverPanic func for turning panics of handlers into internal errors, the stack is logged.
+func recoverPanic(ctx context.Context, spec connect.Spec, _ http.Header, r any) error {
+	logging.FromContext(ctx).ErrorContext(ctx, "Handler panic",
+		"procedure", spec.Procedure,
+		"panic", fmt.Sprint(r),
+		"stack", string(debug.Stack()),
+	)
+
+	return connect.NewError(connect.CodeInternal, errors.New(http.StatusText(http.StatusInternalServerError)))
+}
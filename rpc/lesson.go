@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"opendavinci/controllers"
+	"opendavinci/database"
+	opendavinciv1 "opendavinci/gen/opendavinci/v1"
+	"opendavinci/models"
+	"opendavinci/problem"
+	"opendavinci/render"
+)
+
+// LessonServer struct to implement LessonService like the lesson controllers.
+type LessonServer struct{}
+
+// GetLesson method gets lesson by given ID.
+func (LessonServer) GetLesson(ctx context.Context, req *connect.Request[opendavinciv1.GetLessonRequest]) (*connect.Response[opendavinciv1.GetLessonResponse], error) {
+	lesson, err := getLesson(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&opendavinciv1.GetLessonResponse{Lesson: lessonMessage(ctx, lesson)}), nil
+}
+
+// GetLessonHTML method gets sanitized HTML of lesson content by given ID.
+// The document is not sent again, if the revision of the request is the current one.
+func (LessonServer) GetLessonHTML(ctx context.Context, req *connect.Request[opendavinciv1.GetLessonHTMLRequest]) (*connect.Response[opendavinciv1.GetLessonHTMLResponse], error) {
+	lesson, err := getLesson(ctx, req.Msg.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.GetRevision() == render.Revision(lesson.Format, lesson.Content) {
+		return connect.NewResponse(&opendavinciv1.GetLessonHTMLResponse{NotModified: true}), nil
+	}
+
+	// Render lesson content.
+	doc, err := render.Render(lesson.Format, lesson.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &opendavinciv1.Document{Revision: doc.Revision, Html: doc.HTML}
+	for _, h := range doc.TOC {
+		msg.Toc = append(msg.Toc, &opendavinciv1.Heading{Level: int32(h.Level), Id: h.ID, Title: h.Title})
+	}
+
+	return connect.NewResponse(&opendavinciv1.GetLessonHTMLResponse{Document: msg}), nil
+}
+
+// getLesson func for getting lesson by given ID, or the problem to send.
+func getLesson(ctx context.Context, rawID string) (models.Lesson, error) {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return models.Lesson{}, problem.InvalidID("id", err)
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return models.Lesson{}, err
+	}
+
+	// Get lesson by ID.
+	lesson, err := db.GetLesson(id)
+	if err != nil {
+		return models.Lesson{}, problem.Lookup(err, problem.CodeLessonNotFound, "lesson with the given ID is not found")
+	}
+
+	return lesson, nil
+}
+
+// lessonMessage func for the message of lesson, with signed attachment URLs.
+func lessonMessage(ctx context.Context, lesson models.Lesson) *opendavinciv1.Lesson {
+	controllers.SignLessonAttachments(ctx, &lesson)
+
+	msg := &opendavinciv1.Lesson{
+		Id:          lesson.ID.String(),
+		Created:     timestamppb.New(lesson.Created),
+		LessonId:    lesson.LessonID,
+		CourseId:    lesson.CourseID,
+		Position:    int32(lesson.Position),
+		Title:       lesson.Title,
+		Content:     lesson.Content,
+		Format:      lesson.Format,
+		ResourceUrl: lesson.ResourceURL,
+	}
+	for _, a := range lesson.Attachments {
+		msg.Attachments = append(msg.Attachments, &opendavinciv1.Attachment{
+			Key:         a.Key,
+			Name:        a.Name,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			Uploaded:    timestamppb.New(a.Uploaded),
+			Url:         a.URL,
+		})
+	}
+
+	return msg
+}
@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"opendavinci/logging"
+	"opendavinci/metrics"
+	"opendavinci/tracing"
+)
+
+// observe func for handling requests of the h2c server, which skip the middleware of the app,
+// like the middleware does for routes: the request ID, a server span, HTTP metrics and the access log.
+// Route labels the requests, like the route template of the services in the app.
+func observe(route string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		metrics.HTTPInFlight.Inc()
+		defer metrics.HTTPInFlight.Dec()
+
+		// The ID is set in the request as well, authorize records it with the actor.
+		id := r.Header.Get("X-Request-ID")
+		if !logging.ValidRequestID(id) {
+			id = uuid.NewString()
+			r.Header.Set("X-Request-ID", id)
+		}
+		w.Header().Set("X-Request-ID", id)
+
+		remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			remoteIP = r.RemoteAddr
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.HTTPRoute(route),
+				semconv.ClientAddress(remoteIP),
+				semconv.UserAgentOriginal(r.UserAgent()),
+			),
+		)
+		defer span.End()
+		ctx = logging.WithLogger(ctx, slog.Default().With("requestId", id))
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r.WithContext(ctx))
+		status := sw.callStatus()
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		labels := []string{r.Method, route, strconv.Itoa(status)}
+		metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		metrics.HTTPDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		httpRequest := []any{
+			slog.String("requestMethod", r.Method),
+			slog.String("requestUrl", logging.RedactURL(r.URL.RequestURI())),
+			slog.Int("status", status),
+			slog.String("userAgent", r.UserAgent()),
+			slog.String("remoteIp", remoteIP),
+			slog.String("latency", strconv.FormatFloat(time.Since(start).Seconds(), 'f', 6, 64)+"s"),
+			slog.String("protocol", r.Proto),
+		}
+		if r.ContentLength > 0 {
+			httpRequest = append(httpRequest, slog.String("requestSize", strconv.FormatInt(r.ContentLength, 10)))
+		}
+
+		logging.FromContext(ctx).Log(ctx, level, r.Method+" "+r.URL.Path, slog.Group("httpRequest", httpRequest...))
+	})
+}
+
+// statusWriter struct to describe a response writer keeping the status of the response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader method for keeping status.
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush method for sending buffered data, gRPC needs it for streamed messages.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap method for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// callStatus method for the status of the call: gRPC sends errors with status 200 and grpc-status,
+// in the headers or the trailers, which maps back to the HTTP status of the problem.
+func (w *statusWriter) callStatus() int {
+	grpcStatus := w.Header().Get("Grpc-Status")
+	if grpcStatus == "" {
+		grpcStatus = w.Header().Get(http.TrailerPrefix + "Grpc-Status")
+	}
+	code, err := strconv.Atoi(grpcStatus)
+	if err != nil || code == 0 {
+		return w.status
+	}
+
+	return statusOf(connect.Code(code))
+}
@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	"opendavinci/audit"
+	"opendavinci/config"
+	"opendavinci/controllers"
+	"opendavinci/logging"
+	"opendavinci/metrics"
+	"opendavinci/problem"
+	"opendavinci/ratelimit"
+)
+
+// rateLimitInterceptor func for limiting calls with the buckets of the rate limit policies of routes:
+// calls with an access token like private routes, by its user, or by IP with the anonymous limit as well,
+// other calls like public routes, by IP. Rejected calls get ResourceExhausted with Retry-After.
+// When the store fails, calls are let through and the error is logged.
+func rateLimitInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			cfg := config.Get().RateLimit
+			if !cfg.Enabled {
+				return next(ctx, req)
+			}
+
+			store, err := ratelimit.OpenStore()
+			if err != nil {
+				return nil, err
+			}
+
+			key := peerIP(req)
+			limits := []ratelimit.Limit{ratelimit.ForPolicy(cfg, ratelimit.PolicyAnonymous)}
+			if token := controllers.BearerToken(req.Header().Get("Authorization")); token != "" {
+				limits = append(limits, ratelimit.ForPolicy(cfg, ratelimit.PolicyUser))
+				// Tokens without user are free to mint, their clients are limited by IP as anonymous ones.
+				if claims, err := controllers.ParseTokenMetadata(token); err == nil && claims.Subject != "" {
+					key, limits = audit.ActorID(claims.Subject, ""), limits[1:]
+				}
+			}
+
+			for _, limit := range limits {
+				if err := take(ctx, store, key, limit); err != nil {
+					return nil, err
+				}
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// take func for taking one token from the bucket of key, it returns the error of a rejected call.
+func take(ctx context.Context, store ratelimit.Store, key string, limit ratelimit.Limit) error {
+	res, err := store.Take(ctx, key, limit)
+	if err != nil {
+		metrics.RateLimitErrors.WithLabelValues(limit.Name).Inc()
+		logging.FromContext(ctx).WarnContext(ctx, "Rate limit not checked", "policy", limit.Name, "error", err)
+		return nil
+	}
+	if res.Allowed {
+		return nil
+	}
+
+	metrics.RateLimited.WithLabelValues(limit.Name).Inc()
+	retryAfter := strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds())))
+	cerr := errorOf(problem.New(http.StatusTooManyRequests, problem.CodeRateLimited,
+		"too many requests, retry in "+retryAfter+" seconds"))
+	cerr.Meta().Set("Retry-After", retryAfter)
+
+	return cerr
+}
@@ -0,0 +1,62 @@
+// Package rpc serves CourseService and LessonService of proto/opendavinci/v1
+// over gRPC, gRPC-Web and Connect, with the database and access tokens of the REST API.
+package rpc
+
+import (
+	"net/http"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpcreflect"
+
+	"opendavinci/gen/opendavinci/v1/opendavinciv1connect"
+)
+
+// services func for the handlers of the services by path prefix, e.g. /opendavinci.v1.CourseService/.
+func services() map[string]http.Handler {
+	opts := connect.WithHandlerOptions(
+		connect.WithInterceptors(problemInterceptor(), rateLimitInterceptor()),
+		connect.WithRecover(recoverPanic),
+	)
+
+	courses, courseHandler := opendavinciv1connect.NewCourseServiceHandler(CourseServer{}, opts)
+	lessons, lessonHandler := opendavinciv1connect.NewLessonServiceHandler(LessonServer{}, opts)
+
+	return map[string]http.Handler{
+		courses: courseHandler,
+		lessons: lessonHandler,
+	}
+}
+
+// Handler func for serving the services, unary calls work over HTTP/1.1 with Connect and gRPC-Web.
+// Prefixes are the paths to route to it.
+func Handler() (prefixes []string, h http.Handler) {
+	mux := http.NewServeMux()
+	for prefix, handler := range services() {
+		prefixes = append(prefixes, prefix)
+		mux.Handle(prefix, handler)
+	}
+
+	return prefixes, mux
+}
+
+// NewH2CHandler func for serving the services with server reflection, if set, over HTTP/2.
+// Requests of other paths, e.g. of the REST API, are served by fallback.
+// Calls of the services are observed like requests of the app, see observe.
+func NewH2CHandler(reflection bool, fallback http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	for prefix, handler := range services() {
+		mux.Handle(prefix, observe(prefix+"*", handler))
+	}
+	if reflection {
+		// Reflection lists the services for tools like grpcurl and Postman.
+		reflector := grpcreflect.NewStaticReflector(
+			opendavinciv1connect.CourseServiceName,
+			opendavinciv1connect.LessonServiceName,
+		)
+		mux.Handle(grpcreflect.NewHandlerV1(reflector))
+		mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
+	}
+	mux.Handle("/", fallback)
+
+	return mux
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when a blob with the given key does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Object struct to describe a stored blob.
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
+
+// BlobStore interface to describe a storage for uploaded media.
+type BlobStore interface {
+	// Put stores the content of r under the given key.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Open returns a reader for the blob with the given key.
+	Open(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+
+	// Stat returns the attributes of the blob with the given key.
+	Stat(ctx context.Context, key string) (*Object, error)
+
+	// Delete removes the blob with the given key.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a download URL for the blob which expires after ttl.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
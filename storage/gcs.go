@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+)
+
+// GCSStore struct to describe a blob store in a Google Cloud Storage bucket.
+// Set STORAGE_EMULATOR_HOST to run it against a GCS compatible server.
+type GCSStore struct {
+	client *gcs.Client
+	bucket *gcs.BucketHandle
+}
+
+// NewGCSStore func for create a new store for the given bucket.
+func NewGCSStore(ctx context.Context, bucket string) (*GCSStore, error) {
+	client, err := gcs.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error, not connected to cloud storage, %w", err)
+	}
+
+	return &GCSStore{
+		client: client,
+		bucket: client.Bucket(bucket),
+	}, nil
+}
+
+// Put method for uploading blob to the bucket.
+func (s *GCSStore) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	// Canceling the upload keeps a failed one from being saved partially.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := s.bucket.Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+
+	if _, err := io.Copy(w, r); err != nil {
+		cancel()
+		w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+// Open method for downloading blob from the bucket.
+func (s *GCSStore) Open(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	r, err := s.bucket.Object(key).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, gcs.ErrObjectNotExist) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	return r, &Object{Key: key, ContentType: r.Attrs.ContentType, Size: r.Attrs.Size}, nil
+}
+
+// Stat method for getting attributes of blob in the bucket.
+func (s *GCSStore) Stat(ctx context.Context, key string) (*Object, error) {
+	attrs, err := s.bucket.Object(key).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, gcs.ErrObjectNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &Object{Key: key, ContentType: attrs.ContentType, Size: attrs.Size}, nil
+}
+
+// Delete method for removing blob from the bucket.
+func (s *GCSStore) Delete(ctx context.Context, key string) error {
+	err := s.bucket.Object(key).Delete(ctx)
+	if err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// SignedURL method for building a V4 signed download URL.
+// On Cloud Run the service account signs through the IAM credentials API.
+func (s *GCSStore) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	return s.bucket.SignedURL(key, &gcs.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(ttl),
+		Scheme:  gcs.SigningSchemeV4,
+	})
+}
+
+// Close method for releasing the storage client.
+func (s *GCSStore) Close() error {
+	return s.client.Close()
+}
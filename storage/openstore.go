@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"opendavinci/config"
+)
+
+var (
+	storeMu sync.Mutex
+	store   BlobStore
+)
+
+// OpenBlobStore is our step to switch between diff blob stores (local/gcs).
+// The store is created once and shared by all requests.
+func OpenBlobStore() (BlobStore, error) {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	if store != nil {
+		return store, nil
+	}
+
+	cfg := config.Get().Media
+
+	var err error
+	switch cfg.Store {
+	case "gcs":
+		store, err = NewGCSStore(context.Background(), cfg.GCSBucket)
+	case "", "local":
+		store, err = NewLocalStore(cfg.LocalDir, cfg.BaseURL, []byte(cfg.URLSecret))
+	default:
+		err = fmt.Errorf("unknown media store %q", cfg.Store)
+	}
+	if err != nil {
+		store = nil
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// CloseBlobStore func for releasing the shared store on shutdown.
+func CloseBlobStore() error {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	c, ok := store.(io.Closer)
+	store = nil
+	if !ok {
+		return nil
+	}
+
+	return c.Close()
+}
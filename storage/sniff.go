@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// extensions maps sniffed content types to the extension used in blob keys.
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"application/zip": ".zip",
+	"text/plain":      ".txt",
+	"audio/mpeg":      ".mp3",
+	"video/mp4":       ".mp4",
+}
+
+// Sniff func for detecting the content type of r from its first bytes.
+// It returns a reader which still yields the whole content.
+func Sniff(r io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", nil, err
+	}
+	head = head[:n]
+
+	// Drop parameters like "; charset=utf-8".
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		contentType = "application/octet-stream"
+	}
+
+	return contentType, io.MultiReader(bytes.NewReader(head), r), nil
+}
+
+// Extension func for getting the blob key extension for a content type.
+func Extension(contentType string) string {
+	if ext, ok := extensions[contentType]; ok {
+		return ext
+	}
+
+	return ".bin"
+}
+
+// ContentType func for getting the content type for a blob key extension.
+func ContentType(ext string) string {
+	for contentType, e := range extensions {
+		if e == ext {
+			return contentType
+		}
+	}
+
+	return "application/octet-stream"
+}
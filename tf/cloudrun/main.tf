@@ -10,6 +10,7 @@ locals {
 
   # TODO move to Secrets Manager
   db_server_url = "postgresql://<your Postgres database>"
+  jwt_secret_key = "<your JWT secret key>"
 }
 
   provider "google" {
@@ -47,8 +48,38 @@ locals {
         value = 60
       }
       env {
-        name  = "SERVER_URL"
-        value = "0.0.0.0:8080"
+        name  = "JWT_SECRET_KEY"
+        value = local.jwt_secret_key
+      }
+      env {
+        name  = "RATELIMIT_STORE"
+        value = "postgres"
+      }
+      env {
+        name  = "RATELIMIT_PROXY_HOPS"
+        value = 1
+      }
+      # SERVER_URL is not set, the server listens on PORT set by Cloud Run.
+
+      # gRPC clients need HTTP/2 end to end, name the port h2c to enable it.
+      # REST requests then arrive over h2c as well and are served by the same app.
+      # ports {
+      #   name           = "h2c"
+      #   container_port = 8080
+      # }
+
+      # Health probes, startup waits for database migrations.
+      startup_probe {
+        http_get {
+          path = "/startupz"
+        }
+        period_seconds    = 5
+        failure_threshold = 60
+      }
+      liveness_probe {
+        http_get {
+          path = "/healthz"
+        }
       }
 
       }
@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// cloudTraceHeader is set by Google load balancers and Cloud Run.
+const cloudTraceHeader = "X-Cloud-Trace-Context"
+
+// CloudTraceContext struct to describe propagator of X-Cloud-Trace-Context
+// header in format TRACE_ID/SPAN_ID;o=OPTIONS, span ID is decimal.
+type CloudTraceContext struct{}
+
+var _ propagation.TextMapPropagator = CloudTraceContext{}
+
+// Inject method for setting the header from span context of ctx.
+func (CloudTraceContext) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	spanID := sc.SpanID()
+	options := "0"
+	if sc.IsSampled() {
+		options = "1"
+	}
+
+	carrier.Set(cloudTraceHeader, sc.TraceID().String()+"/"+
+		strconv.FormatUint(binary.BigEndian.Uint64(spanID[:]), 10)+";o="+options)
+}
+
+// Extract method for getting remote span context from the header.
+// Invalid headers are ignored, the request starts a new trace.
+func (CloudTraceContext) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	header := carrier.Get(cloudTraceHeader)
+	if header == "" {
+		return ctx
+	}
+
+	traceIDPart, rest, ok := strings.Cut(header, "/")
+	if !ok {
+		return ctx
+	}
+	spanIDPart, options, _ := strings.Cut(rest, ";")
+
+	traceID, err := trace.TraceIDFromHex(traceIDPart)
+	if err != nil {
+		return ctx
+	}
+	n, err := strconv.ParseUint(spanIDPart, 10, 64)
+	if err != nil || n == 0 {
+		return ctx
+	}
+	var spanID trace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], n)
+
+	var flags trace.TraceFlags
+	if options == "o=1" {
+		flags = trace.FlagsSampled
+	}
+
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	}))
+}
+
+// Fields method for getting the header names set by Inject.
+func (CloudTraceContext) Fields() []string {
+	return []string{cloudTraceHeader}
+}
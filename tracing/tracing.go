@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"opendavinci/config"
+)
+
+// name of the instrumentation scope of all spans of the application.
+const name = "opendavinci"
+
+// Tracer func for getting the tracer of the application.
+func Tracer() trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Setup func for installing the global tracer provider and propagators.
+// Spans are always created, so trace IDs reach logs, but only exported with TRACING_EXPORTER=otlp.
+// Call the returned func on shutdown to flush spans.
+func Setup(ctx context.Context, cfg config.Tracing) (func(context.Context) error, error) {
+	// W3C traceparent wins over X-Cloud-Trace-Context, if a request has both.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		CloudTraceContext{},
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+		resource.WithFromEnv(), // OTEL_RESOURCE_ATTRIBUTES
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error, not created tracing resource, %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		// Callers decide for their traces, new traces are sampled by ratio.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(cfg.SamplePercent) / 100))),
+	}
+
+	if cfg.Exporter == "otlp" {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error, not created OTLP exporter, %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
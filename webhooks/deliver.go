@@ -0,0 +1,129 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"opendavinci/database"
+	"opendavinci/jobs"
+	"opendavinci/metrics"
+	"opendavinci/models"
+)
+
+// maxResponseBody is how much of the response of a receiver is kept in the delivery log.
+const maxResponseBody = 1 << 10
+
+// userAgent is sent with every delivery.
+const userAgent = "opendavinci-webhooks/1"
+
+// deliver func for sending the delivery of job once and recording the outcome.
+// A failed attempt fails the job, so it is retried until the last attempt.
+func deliver(ctx context.Context, job *jobs.Job[DeliverArgs]) error {
+	db, err := database.OpenDBConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	d, err := db.GetDelivery(job.Args.DeliveryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The webhook was deleted with its deliveries meanwhile.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if d.Status == models.DeliveryDelivered {
+		// The job ran before, but its outcome was not recorded.
+		return nil
+	}
+
+	hook, err := db.GetWebhook(d.WebhookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	a := &models.DeliveryAttempt{Created: time.Now(), DeliveryID: d.ID}
+	if hook.Active {
+		send(ctx, hook, d, a)
+	} else {
+		a.Error = "webhook is not active"
+	}
+	a.DurationMS = time.Since(a.Created).Milliseconds()
+
+	// Set the outcome, a failed attempt is retried until the last one.
+	metrics.WebhookAttempts.WithLabelValues(settle(&d, a, hook.Active, job.RetryAt)).Inc()
+
+	if err := db.RecordAttempt(&d, a); err != nil {
+		return fmt.Errorf("attempt not recorded: %w", err)
+	}
+
+	switch {
+	case a.Error == "":
+		return nil
+	case !hook.Active:
+		return jobs.Permanent(errors.New(a.Error))
+	default:
+		return errors.New(a.Error)
+	}
+}
+
+// settle func for setting the outcome of attempt a on d, a failed attempt is retried at retryAt
+// until the last one, which has no retryAt. It returns the outcome, delivered, retried or failed.
+func settle(d *models.Delivery, a *models.DeliveryAttempt, active bool, retryAt time.Time) string {
+	d.Attempts++
+	d.ResponseStatus = a.ResponseStatus
+	d.Error = a.Error
+	switch {
+	case a.Error == "":
+		d.Status = models.DeliveryDelivered
+		d.NextAttempt = nil
+		return "delivered"
+	case retryAt.IsZero() || !active:
+		d.Status = models.DeliveryFailed
+		d.NextAttempt = nil
+		return "failed"
+	default:
+		d.Status = models.DeliveryPending
+		d.NextAttempt = &retryAt
+		return "retried"
+	}
+}
+
+// send func for posting the payload of d to the webhook, signed with its secret.
+// The receiver must answer with 2xx, anything else sets the error of a.
+func send(ctx context.Context, hook models.Webhook, d models.Delivery, a *models.DeliveryAttempt) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		a.Error = err.Error()
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDelivery, d.ID.String())
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, time.Now(), d.Payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		a.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	a.ResponseStatus = resp.StatusCode
+	a.ResponseBody = strings.ToValidUTF8(string(body), "")
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		a.Error = fmt.Sprintf("receiver answered with status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,144 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"opendavinci/config"
+)
+
+// ErrAddressForbidden is returned for receivers which are not public, e.g. loopback,
+// private, link-local or cloud metadata addresses, unless WEBHOOKS_ALLOW_PRIVATE is set.
+var ErrAddressForbidden = errors.New("webhook receiver address is not public")
+
+// maxRedirects is how many redirects of a receiver are followed.
+const maxRedirects = 5
+
+// nonPublic are the ranges of special-purpose addresses which net.IP methods do not cover.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // this network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, maps to any IPv4 address
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local NAT64
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+}
+
+// publicAddr func for checking, that addr may be reached by deliveries.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, p := range nonPublic {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckURL func for checking the URL of a webhook before it is stored: http or https,
+// with a host whose addresses are all public. Hosts which do not resolve now are accepted,
+// deliveries check the address they connect to anyway.
+func CheckURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	allowPrivate := config.Get().Webhooks.AllowPrivate
+	if err := checkTarget(u, allowPrivate); err != nil || allowPrivate {
+		return err
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !publicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrAddressForbidden, u.Hostname(), addr)
+		}
+	}
+
+	return nil
+}
+
+// checkTarget func for checking the scheme and host of a receiver or its redirect,
+// without resolving the host.
+func checkTarget(u *url.URL, allowPrivate bool) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook URL scheme %q is not http or https", u.Scheme)
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return errors.New("webhook URL has no host")
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !publicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrAddressForbidden, host)
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return fmt.Errorf("%w: %s", ErrAddressForbidden, host)
+	}
+
+	return nil
+}
+
+// newClient func for the client of deliveries. It connects to public addresses only,
+// whatever the host resolves to at the time, also when it follows redirects.
+// Proxies from the environment are not used, they would connect for the client.
+func newClient(cfg config.Webhooks) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = dialControl
+	}
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkTarget(req.URL, cfg.AllowPrivate)
+		},
+	}
+}
+
+// dialControl func for rejecting connections to addresses which are not public,
+// it runs after the host was resolved, for every address tried.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !publicAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrAddressForbidden, host)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Package webhooks delivers events to the webhooks of partner systems.
+// Triggers queue a delivery and its webhook.deliver job for each subscribed webhook
+// in the transaction of the change. The job sends the delivery signed with the secret
+// of the webhook and records every attempt, failed attempts are retried by the job queue.
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"opendavinci/config"
+	"opendavinci/database"
+	"opendavinci/jobs"
+	"opendavinci/models"
+)
+
+// Event types of webhooks, queued by the triggers of the webhooks migration.
+const (
+	EventCoursePublished     = "course.published"     // published is set for the first time
+	EventEnrollmentCompleted = "enrollment.completed" // completed is set for the first time
+	EventPing                = "ping"                 // sent on request to test a receiver
+)
+
+// Types are the event types webhooks subscribe to.
+var Types = []string{EventCoursePublished, EventEnrollmentCompleted}
+
+// DeliverArgs struct to describe the job attempting a delivery, queued with the delivery ID as unique key.
+type DeliverArgs struct {
+	DeliveryID uuid.UUID `json:"deliveryId"`
+}
+
+func (DeliverArgs) Kind() string { return "webhook.deliver" }
+
+// sweepArgs struct to describe the periodic job deleting old deliveries.
+type sweepArgs struct{}
+
+func (sweepArgs) Kind() string { return "webhook.sweep" }
+
+// sweepInterval is how often old deliveries are deleted.
+const sweepInterval = time.Hour
+
+// client sends the deliveries of this process, to public addresses only.
+var client = newClient(config.Webhooks{})
+
+// Register func for delivering queued events by the job worker of this process, before it is started.
+// Retries of failed attempts are the retries of their jobs.
+func Register(cfg config.Webhooks) {
+	client = newClient(cfg)
+
+	jobs.Register(deliver, jobs.Options{
+		MaxAttempts: cfg.MaxAttempts,
+		RetryMin:    cfg.RetryMin,
+		RetryMax:    cfg.RetryMax,
+		Timeout:     cfg.Timeout + time.Minute, // recording the attempt included
+	})
+
+	jobs.Register(func(ctx context.Context, _ *jobs.Job[sweepArgs]) error {
+		db, err := database.OpenDBConnection(ctx)
+		if err != nil {
+			return err
+		}
+		return db.DeleteDeliveries(time.Now().Add(-cfg.Retention))
+	}, jobs.Options{MaxAttempts: 1})
+	jobs.Periodic(sweepArgs{}, sweepInterval)
+}
+
+// Enqueue func for queueing delivery d with its job, both are created or neither.
+func Enqueue(db *database.Queries, d *models.Delivery) error {
+	return db.InTx(func(tx *database.Queries) error {
+		if err := tx.CreateDelivery(d); err != nil {
+			return err
+		}
+		_, err := jobs.Enqueue(tx, DeliverArgs{DeliveryID: d.ID}, &jobs.EnqueueOptions{UniqueKey: d.ID.String()})
+		return err
+	})
+}
+
+// NewSecret func for creating a random secret for a webhook.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// payload struct to describe the body of a delivery, the same as built by the triggers.
+type payload struct {
+	ID      uuid.UUID `json:"id"`
+	Type    string    `json:"type"`
+	Created time.Time `json:"created"`
+	Data    any       `json:"data"`
+}
+
+// NewDelivery func for creating a pending delivery of a new event with data to the webhook.
+func NewDelivery(webhookID uuid.UUID, event string, data any) (*models.Delivery, error) {
+	now := time.Now()
+	eventID := uuid.New()
+
+	body, err := json.Marshal(payload{ID: eventID, Type: event, Created: now, Data: data})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Delivery{
+		ID:          uuid.New(),
+		Created:     now,
+		WebhookID:   webhookID,
+		EventID:     eventID,
+		Event:       event,
+		Payload:     body,
+		Status:      models.DeliveryPending,
+		NextAttempt: &now,
+	}, nil
+}
+
+// Redelivery func for creating a pending delivery of the event of d again,
+// the payload and event ID are kept, so receivers can tell it is the same event.
+func Redelivery(d models.Delivery) *models.Delivery {
+	now := time.Now()
+
+	return &models.Delivery{
+		ID:          uuid.New(),
+		Created:     now,
+		WebhookID:   d.WebhookID,
+		EventID:     d.EventID,
+		Event:       d.Event,
+		Payload:     d.Payload,
+		Redelivery:  true,
+		Status:      models.DeliveryPending,
+		NextAttempt: &now,
+	}
+}
@@ -0,0 +1,253 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"opendavinci/config"
+	"opendavinci/models"
+)
+
+// receiver struct to describe a local receiver of deliveries, it verifies each one like partners should.
+type receiver struct {
+	*httptest.Server
+	secret string
+
+	mu         sync.Mutex
+	statuses   []int // answers to the next deliveries, then 204
+	deliveries []receivedDelivery
+}
+
+// receivedDelivery struct to describe a delivery the receiver got.
+type receivedDelivery struct {
+	ID     string
+	Event  string
+	Body   []byte
+	Verify error
+}
+
+func newReceiver(t *testing.T, secret string, statuses ...int) *receiver {
+	t.Helper()
+
+	// Deliveries to the local receiver are allowed in tests only.
+	saved := client
+	client = newClient(config.Webhooks{Timeout: 5 * time.Second, AllowPrivate: true})
+	t.Cleanup(func() { client = saved })
+
+	r := &receiver{secret: secret, statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.handle))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *receiver) handle(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries = append(r.deliveries, receivedDelivery{
+		ID:     req.Header.Get(HeaderDelivery),
+		Event:  req.Header.Get(HeaderEvent),
+		Body:   body,
+		Verify: Verify(r.secret, req.Header.Get(HeaderSignature), body, 5*time.Minute),
+	})
+
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (r *receiver) received() []receivedDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]receivedDelivery(nil), r.deliveries...)
+}
+
+// newHook func for an active webhook of the receiver.
+func newHook(t *testing.T, r *receiver) models.Webhook {
+	t.Helper()
+
+	return models.Webhook{ID: uuid.New(), URL: r.URL, Secret: r.secret, Active: true}
+}
+
+// attempt func for sending d once and setting its outcome, as the job does.
+func attempt(hook models.Webhook, d *models.Delivery, retryAt time.Time) (*models.DeliveryAttempt, string) {
+	a := &models.DeliveryAttempt{Created: time.Now(), DeliveryID: d.ID}
+	send(context.Background(), hook, *d, a)
+
+	return a, settle(d, a, hook.Active, retryAt)
+}
+
+func TestSendSigned(t *testing.T) {
+	r := newReceiver(t, "secret")
+	hook := newHook(t, r)
+
+	d, err := NewDelivery(hook.ID, EventCoursePublished, map[string]string{"title": "Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, outcome := attempt(hook, d, time.Time{})
+	if outcome != "delivered" || a.Error != "" || a.ResponseStatus != http.StatusNoContent {
+		t.Fatalf("outcome = %s, error = %q, status = %d", outcome, a.Error, a.ResponseStatus)
+	}
+	if d.Status != models.DeliveryDelivered || d.Attempts != 1 || d.NextAttempt != nil {
+		t.Errorf("status = %s, attempts = %d, next attempt = %v", d.Status, d.Attempts, d.NextAttempt)
+	}
+
+	got := r.received()
+	if len(got) != 1 {
+		t.Fatalf("%d deliveries received, want 1", len(got))
+	}
+	if got[0].Verify != nil {
+		t.Errorf("signature not verified: %v", got[0].Verify)
+	}
+	if got[0].ID != d.ID.String() || got[0].Event != EventCoursePublished || string(got[0].Body) != string(d.Payload) {
+		t.Errorf("received %s %s %s", got[0].ID, got[0].Event, got[0].Body)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"ping"}`)
+	header := Sign("secret", time.Now(), body)
+
+	if err := Verify("secret", header, body, time.Minute); err != nil {
+		t.Errorf("valid signature: %v", err)
+	}
+	if err := Verify("other", header, body, time.Minute); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("other secret: %v", err)
+	}
+	if err := Verify("secret", header, []byte(`{"type":"pong"}`), time.Minute); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("changed body: %v", err)
+	}
+	if err := Verify("secret", "v1=00", body, time.Minute); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("no time: %v", err)
+	}
+
+	old := Sign("secret", time.Now().Add(-time.Hour), body)
+	if err := Verify("secret", old, body, time.Minute); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("old signature: %v", err)
+	}
+	if err := Verify("secret", old, body, 0); err != nil {
+		t.Errorf("old signature without tolerance: %v", err)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	r := newReceiver(t, "secret", http.StatusInternalServerError, http.StatusServiceUnavailable)
+	hook := newHook(t, r)
+
+	d, err := NewDelivery(hook.ID, EventEnrollmentCompleted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Failed attempts are pending until the retry the job was scheduled for.
+	for i, status := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		retryAt := time.Now().Add(time.Duration(i+1) * time.Minute)
+		a, outcome := attempt(hook, d, retryAt)
+		if outcome != "retried" || a.ResponseStatus != status || a.Error == "" {
+			t.Fatalf("attempt %d: outcome = %s, status = %d, error = %q", i+1, outcome, a.ResponseStatus, a.Error)
+		}
+		if d.Status != models.DeliveryPending || d.NextAttempt == nil || !d.NextAttempt.Equal(retryAt) {
+			t.Fatalf("attempt %d: status = %s, next attempt = %v", i+1, d.Status, d.NextAttempt)
+		}
+	}
+
+	a, outcome := attempt(hook, d, time.Now().Add(4*time.Minute))
+	if outcome != "delivered" || a.Error != "" {
+		t.Fatalf("attempt 3: outcome = %s, error = %q", outcome, a.Error)
+	}
+	if d.Status != models.DeliveryDelivered || d.Attempts != 3 || d.Error != "" || d.NextAttempt != nil {
+		t.Errorf("status = %s, attempts = %d, error = %q", d.Status, d.Attempts, d.Error)
+	}
+
+	// Every attempt is signed again.
+	for i, got := range r.received() {
+		if got.Verify != nil || got.ID != d.ID.String() {
+			t.Errorf("attempt %d: delivery %s, %v", i+1, got.ID, got.Verify)
+		}
+	}
+}
+
+func TestRetryLastAttempt(t *testing.T) {
+	r := newReceiver(t, "secret", http.StatusBadGateway)
+	hook := newHook(t, r)
+
+	d, err := NewDelivery(hook.ID, EventEnrollmentCompleted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The last attempt has no retry.
+	_, outcome := attempt(hook, d, time.Time{})
+	if outcome != "failed" || d.Status != models.DeliveryFailed || d.NextAttempt != nil {
+		t.Errorf("outcome = %s, status = %s, next attempt = %v", outcome, d.Status, d.NextAttempt)
+	}
+	if d.ResponseStatus != http.StatusBadGateway {
+		t.Errorf("response status = %d, want 502", d.ResponseStatus)
+	}
+}
+
+func TestRedelivery(t *testing.T) {
+	r := newReceiver(t, "secret")
+	hook := newHook(t, r)
+
+	d, err := NewDelivery(hook.ID, EventCoursePublished, map[string]string{"title": "Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempt(hook, d, time.Time{})
+
+	again := Redelivery(*d)
+	if !again.Redelivery || again.ID == d.ID || again.EventID != d.EventID || again.Status != models.DeliveryPending {
+		t.Fatalf("redelivery %+v", again)
+	}
+	if _, outcome := attempt(hook, again, time.Time{}); outcome != "delivered" {
+		t.Fatalf("outcome = %s", outcome)
+	}
+
+	// The receiver gets the same event in a new delivery.
+	got := r.received()
+	if len(got) != 2 {
+		t.Fatalf("%d deliveries received, want 2", len(got))
+	}
+	if got[1].Verify != nil || got[1].ID != again.ID.String() || got[1].ID == got[0].ID {
+		t.Errorf("redelivery %s: %v", got[1].ID, got[1].Verify)
+	}
+	if string(got[1].Body) != string(got[0].Body) {
+		t.Errorf("payload changed: %s", got[1].Body)
+	}
+}
+
+func TestPrivateReceiver(t *testing.T) {
+	r := newReceiver(t, "secret")
+	hook := newHook(t, r)
+
+	// Deliveries of the default client never reach local addresses.
+	client = newClient(config.Webhooks{Timeout: 5 * time.Second})
+
+	d, err := NewDelivery(hook.ID, EventPing, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, outcome := attempt(hook, d, time.Now().Add(time.Minute))
+	if outcome != "retried" || a.ResponseStatus != 0 || len(r.received()) != 0 {
+		t.Errorf("outcome = %s, status = %d, error = %q", outcome, a.ResponseStatus, a.Error)
+	}
+	if err := CheckURL(context.Background(), r.URL); !errors.Is(err, ErrAddressForbidden) {
+		t.Errorf("CheckURL(%s) = %v", r.URL, err)
+	}
+}